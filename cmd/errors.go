@@ -0,0 +1,31 @@
+package cmd
+
+import "github.com/toss/apps-in-toss-ax/pkg/search"
+
+// 명령이 실패했을 때의 종료 코드입니다. 스크립트에서 실패 원인에 따라 재시도 여부를 정할 수 있도록
+// search.ErrorCode마다 다른 값을 사용하며, 분류되지 않은 에러는 1입니다.
+const (
+	exitInternal         = 1
+	exitInvalidArgument  = 2
+	exitNotFound         = 3
+	exitIndexUnavailable = 4
+	exitNetwork          = 5
+)
+
+// ExitCode는 명령이 반환한 err에 해당하는 종료 코드를 반환합니다. err가 nil이면 0입니다.
+func ExitCode(err error) int {
+	switch search.CodeOf(err) {
+	case "":
+		return 0
+	case search.ErrorInvalidArgument:
+		return exitInvalidArgument
+	case search.ErrorNotFound:
+		return exitNotFound
+	case search.ErrorIndexUnavailable:
+		return exitIndexUnavailable
+	case search.ErrorNetwork:
+		return exitNetwork
+	default:
+		return exitInternal
+	}
+}
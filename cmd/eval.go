@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/toss/apps-in-toss-ax/pkg/eval"
+	"github.com/toss/apps-in-toss-ax/pkg/search"
+)
+
+type evalFlags struct {
+	refreshFlags
+	boostFlags
+
+	golden string
+	k      int
+	json   bool
+}
+
+func NewEvalCommand() *cobra.Command {
+	var flags evalFlags
+
+	cmd := &cobra.Command{
+		Use:   "eval",
+		Short: "Evaluate search relevance against a golden query set",
+		Long: `Evaluate search relevance against a golden query set.
+
+The golden set is a JSON file listing queries and the document URLs expected for each:
+
+  {
+    "queries": [
+      {"query": "결제 연동", "corpus": "docs", "expected": ["https://developers-apps-in-toss.toss.im/..."]}
+    ]
+  }
+
+corpus is one of docs (default), tds-rn or tds-web. Reports MRR, nDCG@k and recall@k per query and on average.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runEval(cmd, &flags)
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.golden, "golden", "", "Path to the golden query set JSON file (required)")
+	cmd.Flags().IntVar(&flags.k, "k", eval.DefaultK, "Number of top results used for nDCG and recall")
+	cmd.Flags().BoolVar(&flags.json, "json", false, "Print the report as JSON")
+	cmd.MarkFlagRequired("golden")
+	flags.boostFlags.register(cmd)
+	flags.refreshFlags.register(cmd)
+
+	return cmd
+}
+
+func runEval(cmd *cobra.Command, flags *evalFlags) error {
+	ctx := cmd.Context()
+
+	set, err := eval.LoadGoldenSet(flags.golden)
+	if err != nil {
+		return err
+	}
+
+	// 코퍼스마다 Searcher를 한 번만 열어 재사용합니다
+	searchers := map[string]*search.Searcher{}
+	defer func() {
+		for _, s := range searchers {
+			s.Close()
+		}
+	}()
+
+	searchFn := func(ctx context.Context, q eval.GoldenQuery, limit int) ([]string, error) {
+		corpus := q.Corpus
+		if corpus == "" {
+			corpus = "docs"
+		}
+
+		s, ok := searchers[corpus]
+		if !ok {
+			factory, err := corpusFactory(corpus)
+			if err != nil {
+				return nil, err
+			}
+			if s, err = factory(); err != nil {
+				return nil, err
+			}
+			searchers[corpus] = s
+			s.SetRefreshPolicy(flags.policy())
+			if err := s.EnsureIndex(ctx, nil); err != nil {
+				return nil, err
+			}
+		}
+
+		profile := flags.profile
+		if q.Profile != "" {
+			profile = q.Profile
+		}
+
+		results, err := s.Search(ctx, q.Query, &search.SearchOptions{
+			Limit:   limit,
+			Boosts:  flags.overrides(cmd),
+			Syntax:  q.Syntax,
+			Profile: profile,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		urls := make([]string, len(results))
+		for i, r := range results {
+			urls[i] = r.URL
+		}
+		return urls, nil
+	}
+
+	report, err := eval.Run(ctx, set, flags.k, searchFn)
+	if err != nil {
+		return err
+	}
+
+	if flags.json {
+		output, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(output))
+		return nil
+	}
+
+	printEvalReport(cmd, report)
+	return nil
+}
+
+func printEvalReport(cmd *cobra.Command, report *eval.Report) {
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "RR\tnDCG@%d\tRecall@%d\tRank\tQuery\n", report.K, report.K)
+	for _, q := range report.Queries {
+		rank := "-"
+		if q.FirstHit > 0 {
+			rank = fmt.Sprint(q.FirstHit)
+		}
+		fmt.Fprintf(w, "%.3f\t%.3f\t%.3f\t%s\t%s\n", q.ReciprocalRank, q.NDCG, q.Recall, rank, q.Query)
+	}
+	fmt.Fprintf(w, "%.3f\t%.3f\t%.3f\t\tmean of %d queries (MRR, nDCG@%d, Recall@%d)\n",
+		report.Summary.MRR, report.Summary.NDCG, report.Summary.Recall, len(report.Queries), report.K, report.K)
+	w.Flush()
+
+	for _, q := range report.Queries {
+		for _, u := range q.Missing {
+			fmt.Fprintf(cmd.OutOrStdout(), "missing %q: %s\n", q.Query, u)
+		}
+	}
+}
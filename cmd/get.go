@@ -2,7 +2,10 @@ package cmd
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"sync"
+	"unicode/utf8"
 
 	"github.com/spf13/cobra"
 	"github.com/toss/apps-in-toss-ax/pkg/search"
@@ -17,87 +20,310 @@ func NewGetCommand() *cobra.Command {
 	cmd.AddCommand(newGetDocCommand())
 	cmd.AddCommand(newGetTdsRnCommand())
 	cmd.AddCommand(newGetTdsWebCommand())
+	cmd.AddCommand(newGetOutlineCommand())
 
 	return cmd
 }
 
 func newGetDocCommand() *cobra.Command {
-	var id string
+	var ids []string
+	var refresh refreshFlags
+	var page pageFlags
 
 	cmd := &cobra.Command{
 		Use:   "doc",
 		Short: "Get an AppsInToss document by ID",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runGetDoc(cmd, search.New, id)
+			return runGetDoc(cmd, search.New, ids, &refresh, &page)
 		},
 	}
 
-	cmd.Flags().StringVar(&id, "id", "", "Document ID (required)")
+	cmd.Flags().StringArrayVar(&ids, "id", nil, "Document ID (required; repeat to fetch several documents)")
 	cmd.MarkFlagRequired("id")
+	page.register(cmd)
+	refresh.register(cmd)
 
 	return cmd
 }
 
 func newGetTdsRnCommand() *cobra.Command {
-	var id string
+	var ids []string
+	var refresh refreshFlags
+	var page pageFlags
 
 	cmd := &cobra.Command{
 		Use:   "tds-rn",
 		Short: "Get a TDS React Native document by ID",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runGetDoc(cmd, search.NewTDSSearcher, id)
+			return runGetDoc(cmd, search.NewTDSSearcher, ids, &refresh, &page)
 		},
 	}
 
-	cmd.Flags().StringVar(&id, "id", "", "Document ID (required)")
+	cmd.Flags().StringArrayVar(&ids, "id", nil, "Document ID (required; repeat to fetch several documents)")
 	cmd.MarkFlagRequired("id")
+	page.register(cmd)
+	refresh.register(cmd)
 
 	return cmd
 }
 
 func newGetTdsWebCommand() *cobra.Command {
-	var id string
+	var ids []string
+	var refresh refreshFlags
+	var page pageFlags
 
 	cmd := &cobra.Command{
 		Use:   "tds-web",
 		Short: "Get a TDS Web document by ID",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runGetDoc(cmd, search.NewTDSMobileSearcher, id)
+			return runGetDoc(cmd, search.NewTDSMobileSearcher, ids, &refresh, &page)
+		},
+	}
+
+	cmd.Flags().StringArrayVar(&ids, "id", nil, "Document ID (required; repeat to fetch several documents)")
+	cmd.MarkFlagRequired("id")
+	page.register(cmd)
+	refresh.register(cmd)
+
+	return cmd
+}
+
+func newGetOutlineCommand() *cobra.Command {
+	var id, corpus string
+	var refresh refreshFlags
+
+	cmd := &cobra.Command{
+		Use:   "outline",
+		Short: "Get the heading outline of a document with section sizes",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			factory, err := corpusFactory(corpus)
+			if err != nil {
+				return err
+			}
+			return runGetOutline(cmd, factory, id, &refresh)
 		},
 	}
 
 	cmd.Flags().StringVar(&id, "id", "", "Document ID (required)")
+	cmd.Flags().StringVar(&corpus, "corpus", "docs", "Corpus the document belongs to (docs, tds-rn, tds-web)")
 	cmd.MarkFlagRequired("id")
+	refresh.register(cmd)
 
 	return cmd
 }
 
-func runGetDoc(cmd *cobra.Command, factory searcherFactory, id string) error {
+// pageFlags는 긴 문서를 나눠 읽기 위한 크기 제한과 시작 위치 플래그입니다
+type pageFlags struct {
+	maxTokens int
+	maxChars  int
+	offset    int
+	section   string
+	startLine int
+	endLine   int
+}
+
+func (f *pageFlags) register(cmd *cobra.Command) {
+	cmd.Flags().IntVar(&f.maxTokens, "max-tokens", 0, "Maximum estimated tokens of content (0 for the whole document)")
+	cmd.Flags().IntVar(&f.maxChars, "max-chars", 0, "Maximum characters of content (0 for the whole document)")
+	cmd.Flags().IntVar(&f.offset, "offset", 0, "Character offset to start from (the next offset is printed to stderr)")
+	cmd.Flags().StringVar(&f.section, "section", "", "Heading text or anchor to start from")
+	cmd.Flags().IntVar(&f.startLine, "start-line", 0, "First line to print (1-based); cannot be combined with the other paging flags")
+	cmd.Flags().IntVar(&f.endLine, "end-line", 0, "Last line to print (inclusive; 0 for the end of the document)")
+}
+
+// paginate는 줄 범위가 지정되면 그 줄만, 아니면 크기 제한과 시작 위치에 따라 content를 자릅니다
+func (f *pageFlags) paginate(content string) (search.Page, error) {
+	if f.startLine <= 0 && f.endLine <= 0 {
+		return search.PaginateContent(content, f.options())
+	}
+	if f.maxTokens > 0 || f.maxChars > 0 || f.offset > 0 || f.section != "" {
+		return search.Page{}, search.InvalidArgument(errors.New("--start-line and --end-line cannot be combined with --max-tokens, --max-chars, --offset or --section"))
+	}
+	page, _, err := search.LineRange(content, f.startLine, f.endLine)
+	return page, err
+}
+
+func (f *pageFlags) options() search.PageOptions {
+	return search.PageOptions{
+		Offset:    f.offset,
+		Section:   f.section,
+		MaxChars:  f.maxChars,
+		MaxTokens: f.maxTokens,
+	}
+}
+
+func runGetDoc(cmd *cobra.Command, factory searcherFactory, ids []string, refresh *refreshFlags, page *pageFlags) error {
 	ctx := cmd.Context()
 
+	if len(ids) > 1 && (page.maxChars > 0 || page.offset > 0 || page.section != "" || page.startLine > 0 || page.endLine > 0) {
+		return search.InvalidArgument(errors.New("--max-chars, --offset, --section, --start-line and --end-line can only be used with a single --id"))
+	}
+
 	s, err := factory()
 	if err != nil {
 		return err
 	}
 	defer s.Close()
 
-	if err := s.EnsureIndex(ctx); err != nil {
+	s.SetRefreshPolicy(refresh.policy())
+	if err := s.EnsureIndex(ctx, nil); err != nil {
 		return err
 	}
 
+	if len(ids) > 1 {
+		return printDocuments(cmd, s, ids, page.maxTokens)
+	}
+
+	id := ids[0]
 	doc, err := s.GetDocument(ctx, id)
 	if err != nil {
 		return err
 	}
 	if doc == nil {
-		return fmt.Errorf("document not found: %s", id)
+		return fmt.Errorf("%w: %s", search.ErrDocumentNotFound, id)
+	}
+
+	paged, err := page.paginate(doc.Content)
+	if err != nil {
+		return err
 	}
+	doc.Content = paged.Content
 
 	output, err := json.MarshalIndent(doc, "", "  ")
 	if err != nil {
 		return err
 	}
 
+	fmt.Fprintln(cmd.OutOrStdout(), string(output))
+	if paged.HasMore {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Showing characters %d-%d of %d (~%d of %d tokens). Continue with --offset %d\n",
+			paged.Offset, paged.End, paged.TotalChars, paged.EstimatedTokens, paged.TotalTokens, paged.End)
+	}
+	return nil
+}
+
+// batchDoc은 여러 ID를 조회할 때 ID 하나의 결과입니다. 찾지 못한 ID는 Error만 채워집니다.
+type batchDoc struct {
+	ID              string               `json:"id"`
+	Document        *search.SearchResult `json:"document,omitempty"`
+	Error           string               `json:"error,omitempty"`
+	Code            search.ErrorCode     `json:"code,omitempty"`
+	HasMore         bool                 `json:"has_more"`
+	NextOffset      int                  `json:"next_offset,omitempty"`
+	EstimatedTokens int                  `json:"estimated_tokens"`
+}
+
+// printDocuments는 ids 문서를 동시에 조회해 요청 순서대로 JSON 배열로 출력합니다.
+// maxTokens는 전체 문서에 걸친 예산이며, 찾지 못한 ID가 있어도 나머지 문서는 출력합니다.
+func printDocuments(cmd *cobra.Command, s *search.Searcher, ids []string, maxTokens int) error {
+	ctx := cmd.Context()
+
+	docs := make([]batchDoc, len(ids))
+	found := make([]*search.SearchResult, len(ids))
+	var wg sync.WaitGroup
+	for i, id := range ids {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			docs[i] = batchDoc{ID: id}
+			doc, err := s.GetDocument(ctx, id)
+			switch {
+			case err != nil:
+				docs[i].Error, docs[i].Code = err.Error(), search.CodeOf(err)
+			case doc == nil:
+				docs[i].Error, docs[i].Code = fmt.Sprintf("%s: %s", search.ErrDocumentNotFound, id), search.ErrorNotFound
+			default:
+				found[i] = doc
+			}
+		}()
+	}
+	wg.Wait()
+
+	var contents []string
+	for _, doc := range found {
+		if doc != nil {
+			contents = append(contents, doc.Content)
+		}
+	}
+	pages := search.ApplyTokenBudget(contents, maxTokens)
+
+	next, truncated := 0, 0
+	for i, doc := range found {
+		if doc == nil {
+			fmt.Fprintln(cmd.ErrOrStderr(), docs[i].Error)
+			continue
+		}
+		page := pages[next]
+		next++
+
+		doc.Content = page.Content
+		docs[i].Document = doc
+		docs[i].HasMore = page.HasMore
+		docs[i].EstimatedTokens = page.EstimatedTokens
+		if page.HasMore {
+			docs[i].NextOffset = page.End
+			truncated++
+		}
+	}
+
+	output, err := json.MarshalIndent(docs, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(cmd.OutOrStdout(), string(output))
+	if truncated > 0 {
+		fmt.Fprintf(cmd.ErrOrStderr(), "%d document(s) did not fit in --max-tokens %d. Continue one with --id ID --offset NEXT_OFFSET\n", truncated, maxTokens)
+	}
+	return nil
+}
+
+// docOutline은 ax get outline의 출력입니다
+type docOutline struct {
+	ID          string                  `json:"id"`
+	Title       string                  `json:"title"`
+	Sections    []search.OutlineSection `json:"sections"`
+	TotalChars  int                     `json:"total_chars"`
+	TotalTokens int                     `json:"total_tokens"`
+}
+
+func runGetOutline(cmd *cobra.Command, factory searcherFactory, id string, refresh *refreshFlags) error {
+	ctx := cmd.Context()
+
+	s, err := factory()
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+
+	s.SetRefreshPolicy(refresh.policy())
+	if err := s.EnsureIndex(ctx, nil); err != nil {
+		return err
+	}
+
+	doc, err := s.GetDocument(ctx, id)
+	if err != nil {
+		return err
+	}
+	if doc == nil {
+		return fmt.Errorf("%w: %s", search.ErrDocumentNotFound, id)
+	}
+
+	sections := search.Outline(doc.Content)
+	if sections == nil {
+		sections = []search.OutlineSection{}
+	}
+	output, err := json.MarshalIndent(docOutline{
+		ID:          doc.ID,
+		Title:       doc.Title,
+		Sections:    sections,
+		TotalChars:  utf8.RuneCountInString(doc.Content),
+		TotalTokens: search.EstimateTokens(doc.Content),
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+
 	fmt.Fprintln(cmd.OutOrStdout(), string(output))
 	return nil
 }
@@ -1,31 +1,47 @@
 package cmd
 
 import (
+	"fmt"
+
 	"github.com/spf13/cobra"
 	"github.com/toss/apps-in-toss-ax/pkg/features"
 	"github.com/toss/apps-in-toss-ax/pkg/mcp"
+	"github.com/toss/apps-in-toss-ax/pkg/search"
 )
 
 func NewMcpCommand(instrumentation features.InstrumentationFeature) *cobra.Command {
+	var promptDirs []string
 	cmd := &cobra.Command{
 		Use:   "mcp",
 		Short: "Manage MCP (Message Context Protocol) servers",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return startMcpServer(cmd, args, instrumentation)
+			return startMcpServer(cmd, args, instrumentation, promptDirs)
 		},
 	}
 
+	cmd.Flags().StringArrayVar(&promptDirs, "prompt-dir", nil, "Directory of additional prompt Markdown files (repeat to load several directories)")
 	return cmd
 }
 
-func startMcpServer(cmd *cobra.Command, _ []string, instrumentation features.InstrumentationFeature) error {
+func startMcpServer(cmd *cobra.Command, _ []string, instrumentation features.InstrumentationFeature, promptDirs []string) error {
 	analytics := instrumentation.Analytics
 	if usageStatsDisabled(cmd) {
 		analytics = nil
 	}
+
+	var prompts []*mcp.PromptTemplate
+	for _, dir := range promptDirs {
+		templates, err := mcp.LoadPromptDir(dir)
+		if err != nil {
+			return search.InvalidArgument(fmt.Errorf("--prompt-dir: %w", err))
+		}
+		prompts = append(prompts, templates...)
+	}
+
 	p := mcp.New(
 		mcp.WithAnalytics(analytics),
 		mcp.WithVersion(GetVersion().Version),
+		mcp.WithPrompts(prompts...),
 	)
 
 	return p.Server.Run(cmd.Context(), p.Transport)
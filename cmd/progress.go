@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/toss/apps-in-toss-ax/pkg/search"
+)
+
+// progressBarWidth는 진행 막대의 칸 수입니다
+const progressBarWidth = 30
+
+// progressBar는 인덱스를 준비하는 동안 터미널의 한 줄에 진행 상황을 다시 그립니다.
+// stdout의 JSON을 파이프로 받는 스크립트가 영향을 받지 않도록 stderr에 씁니다.
+type progressBar struct {
+	w     io.Writer
+	drawn bool
+}
+
+// newProgressBar는 w가 터미널일 때만 진행 막대를 만듭니다. 파일이나 파이프로 리다이렉트된 경우 nil입니다.
+func newProgressBar(w io.Writer) *progressBar {
+	file, ok := w.(*os.File)
+	if !ok {
+		return nil
+	}
+	info, err := file.Stat()
+	if err != nil || info.Mode()&os.ModeCharDevice == 0 {
+		return nil
+	}
+	return &progressBar{w: w}
+}
+
+// report는 EnsureIndex에 넘길 ProgressFunc입니다. 진행 막대가 nil이면 nil을 반환합니다.
+func (b *progressBar) report() search.ProgressFunc {
+	if b == nil {
+		return nil
+	}
+	return b.update
+}
+
+func (b *progressBar) update(progress search.Progress) {
+	fmt.Fprintf(b.w, "\r\033[K%s", progressLine(progress))
+	b.drawn = true
+}
+
+// clear는 결과를 출력하기 전에 진행 막대를 지웁니다
+func (b *progressBar) clear() {
+	if b == nil || !b.drawn {
+		return
+	}
+	fmt.Fprint(b.w, "\r\033[K")
+	b.drawn = false
+}
+
+// progressLine은 진행 상황 한 줄입니다. 전체 양을 아는 단계에서는 막대를 함께 그립니다.
+func progressLine(progress search.Progress) string {
+	if progress.Total <= 0 {
+		return progress.Message
+	}
+	filled := progressBarWidth * min(progress.Current, progress.Total) / progress.Total
+	return fmt.Sprintf("[%s%s] %s", strings.Repeat("#", filled), strings.Repeat(" ", progressBarWidth-filled), progress.Message)
+}
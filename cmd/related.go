@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/toss/apps-in-toss-ax/pkg/search"
+)
+
+// corpusFactories는 --corpus 값에 해당하는 Searcher 생성 함수입니다 (search/get 하위 명령 이름과 같음)
+var corpusFactories = map[string]searcherFactory{
+	"docs":    search.New,
+	"tds-rn":  search.NewTDSSearcher,
+	"tds-web": search.NewTDSMobileSearcher,
+}
+
+func corpusFactory(corpus string) (searcherFactory, error) {
+	factory, ok := corpusFactories[corpus]
+	if !ok {
+		return nil, search.InvalidArgument(fmt.Errorf("unknown corpus %q (supported: docs, tds-rn, tds-web)", corpus))
+	}
+	return factory, nil
+}
+
+func NewRelatedCommand() *cobra.Command {
+	var id, corpus string
+	var limit int
+	var refresh refreshFlags
+
+	cmd := &cobra.Command{
+		Use:   "related",
+		Short: "Find documents related to a document",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			factory, err := corpusFactory(corpus)
+			if err != nil {
+				return err
+			}
+			return runRelated(cmd, factory, id, limit, &refresh)
+		},
+	}
+
+	cmd.Flags().StringVar(&id, "id", "", "Document ID (required)")
+	cmd.Flags().StringVar(&corpus, "corpus", "docs", "Corpus the document belongs to (docs, tds-rn, tds-web)")
+	cmd.Flags().IntVar(&limit, "limit", 5, "Maximum number of related documents")
+	cmd.MarkFlagRequired("id")
+	refresh.register(cmd)
+
+	return cmd
+}
+
+func runRelated(cmd *cobra.Command, factory searcherFactory, id string, limit int, refresh *refreshFlags) error {
+	ctx := cmd.Context()
+
+	s, err := factory()
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+
+	s.SetRefreshPolicy(refresh.policy())
+	if err := s.EnsureIndex(ctx, nil); err != nil {
+		return err
+	}
+
+	results, err := s.RelatedDocuments(ctx, id, limit)
+	if err != nil {
+		return err
+	}
+
+	output, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(cmd.OutOrStdout(), string(output))
+	return nil
+}
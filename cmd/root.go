@@ -9,6 +9,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"github.com/toss/apps-in-toss-ax/pkg/features"
+	"github.com/toss/apps-in-toss-ax/pkg/search"
 )
 
 const disableUsageStatsFlag = "disable-usage-stats"
@@ -39,6 +40,11 @@ func NewCommand(cfg CommandConfig) *cobra.Command {
 		})
 	}
 
+	// 잘못된 플래그 값도 invalid_argument 종료 코드로 끝나도록 분류합니다
+	cmd.SetFlagErrorFunc(func(_ *cobra.Command, err error) error {
+		return search.InvalidArgument(err)
+	})
+
 	defaultHelp := cmd.HelpFunc()
 	cmd.SetHelpFunc(func(cmd *cobra.Command, args []string) {
 		if cmd.Root() == cmd {
@@ -51,6 +57,8 @@ func NewCommand(cfg CommandConfig) *cobra.Command {
 	cmd.AddCommand(NewVersionCommand())
 	cmd.AddCommand(NewSearchCommand())
 	cmd.AddCommand(NewGetCommand())
+	cmd.AddCommand(NewRelatedCommand())
+	cmd.AddCommand(NewEvalCommand())
 
 	return cmd
 }
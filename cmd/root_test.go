@@ -1,9 +1,13 @@
 package cmd
 
 import (
+	"errors"
+	"fmt"
+	"io"
 	"testing"
 
 	"github.com/spf13/cobra"
+	"github.com/toss/apps-in-toss-ax/pkg/search"
 )
 
 func TestUsageStatsDisabled(t *testing.T) {
@@ -41,3 +45,32 @@ func TestUsageStatsDisabledLocalFlag(t *testing.T) {
 		t.Fatal("usageStatsDisabled = false after local flag is set")
 	}
 }
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{nil, 0},
+		{fmt.Errorf("%w: abc", search.ErrDocumentNotFound), exitNotFound},
+		{search.InvalidArgument(errors.New("unknown corpus")), exitInvalidArgument},
+		{search.IndexUnavailable(errors.New("corrupted")), exitIndexUnavailable},
+		{errors.New("boom"), exitInternal},
+	}
+	for _, tt := range tests {
+		if got := ExitCode(tt.err); got != tt.want {
+			t.Errorf("ExitCode(%v) = %d, want %d", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestExitCode_FlagErrors(t *testing.T) {
+	root := NewCommand(CommandConfig{Name: "ax"})
+	root.SetArgs([]string{"search", "docs", "--query", "결제", "--limit", "many"})
+	root.SetOut(io.Discard)
+	root.SetErr(io.Discard)
+
+	if got := ExitCode(root.Execute()); got != exitInvalidArgument {
+		t.Errorf("Expected exit code %d for an invalid flag value, got %d", exitInvalidArgument, got)
+	}
+}
@@ -3,6 +3,9 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"io"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/toss/apps-in-toss-ax/pkg/search"
@@ -10,34 +13,98 @@ import (
 
 type searcherFactory func() (*search.Searcher, error)
 
-type searchFlags struct {
-	query            string
-	limit            int
+// refreshFlags는 로컬 인덱스의 신선도 확인 방식을 제어하는 공통 플래그입니다
+type refreshFlags struct {
+	refresh      bool
+	freshnessTTL time.Duration
+}
+
+func (f *refreshFlags) register(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&f.refresh, "refresh", false, "Check the documentation source for updates even if the local index was validated recently")
+	cmd.Flags().DurationVar(&f.freshnessTTL, "freshness-ttl", 0, fmt.Sprintf("How long a validated index is trusted before checking for updates again; saved for later runs (default %s)", search.DefaultFreshnessTTL))
+}
+
+func (f *refreshFlags) policy() search.RefreshPolicy {
+	return search.RefreshPolicy{
+		Force:        f.refresh,
+		FreshnessTTL: f.freshnessTTL,
+	}
+}
+
+// boostFlags는 검색 가중치를 조정하는 공통 플래그입니다
+type boostFlags struct {
 	titleBoost       float64
 	descriptionBoost float64
 	contentBoost     float64
 	categoryBoost    float64
+	linkBoost        float64
+	profile          string
 }
 
-func (f *searchFlags) register(cmd *cobra.Command) {
-	cmd.Flags().StringVar(&f.query, "query", "", "Search query (required)")
-	cmd.Flags().IntVar(&f.limit, "limit", 10, "Maximum number of results")
+func (f *boostFlags) register(cmd *cobra.Command) {
 	cmd.Flags().Float64Var(&f.titleBoost, "title-boost", search.DefaultTitleBoost, "Relevance boost for title matches")
 	cmd.Flags().Float64Var(&f.descriptionBoost, "description-boost", search.DefaultDescriptionBoost, "Relevance boost for description matches")
 	cmd.Flags().Float64Var(&f.contentBoost, "content-boost", search.DefaultContentBoost, "Relevance boost for content matches")
 	cmd.Flags().Float64Var(&f.categoryBoost, "category-boost", search.DefaultCategoryBoost, "Relevance boost for category matches")
+	cmd.Flags().Float64Var(&f.linkBoost, "link-boost", search.DefaultLinkBoost, "Ranking multiplier for pages that many other pages link to (0 disables)")
+	cmd.Flags().StringVar(&f.profile, "profile", "", fmt.Sprintf("Boost profile for the query intent (%s, %s); explicit --*-boost flags override it", strings.Join(search.ProfileNames(), ", "), search.ProfileAuto))
+}
+
+// overrides는 명시적으로 지정한 부스트 플래그만 재정의로 반환합니다.
+// 지정하지 않은 필드는 프로필 또는 기본값을 따릅니다.
+func (f *boostFlags) overrides(cmd *cobra.Command) search.BoostOverrides {
+	var overrides search.BoostOverrides
+	flags := cmd.Flags()
+	if flags.Changed("title-boost") {
+		overrides.Title = &f.titleBoost
+	}
+	if flags.Changed("description-boost") {
+		overrides.Description = &f.descriptionBoost
+	}
+	if flags.Changed("content-boost") {
+		overrides.Content = &f.contentBoost
+	}
+	if flags.Changed("category-boost") {
+		overrides.Category = &f.categoryBoost
+	}
+	if flags.Changed("link-boost") {
+		overrides.Links = &f.linkBoost
+	}
+	return overrides
+}
+
+type searchFlags struct {
+	refreshFlags
+	boostFlags
+
+	query      string
+	limit      int
+	syntax     bool
+	noFallback bool
+	facets     bool
+	explain    bool
+}
+
+func (f *searchFlags) register(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&f.query, "query", "", "Search query (required)")
+	cmd.Flags().IntVar(&f.limit, "limit", 10, "Maximum number of results")
+	f.boostFlags.register(cmd)
+	cmd.Flags().BoolVar(&f.syntax, "syntax", false, `Interpret the query with search syntax ("phrase", -term, +term, field:term, OR)`)
+	cmd.Flags().BoolVar(&f.noFallback, "no-fallback", false, "Do not retry with relaxed queries when nothing matches")
+	cmd.Flags().BoolVar(&f.facets, "facets", false, "Print the number of matching documents per category to stderr")
+	cmd.Flags().BoolVar(&f.explain, "explain", false, "Include a per-field score breakdown in each result and print it to stderr")
 	cmd.MarkFlagRequired("query")
+	f.refreshFlags.register(cmd)
 }
 
-func (f *searchFlags) options() *search.SearchOptions {
+func (f *searchFlags) options(cmd *cobra.Command) *search.SearchOptions {
 	return &search.SearchOptions{
-		Limit: f.limit,
-		Boosts: search.BoostOverrides{
-			Title:       &f.titleBoost,
-			Description: &f.descriptionBoost,
-			Content:     &f.contentBoost,
-			Category:    &f.categoryBoost,
-		},
+		Limit:           f.limit,
+		Boosts:          f.boostFlags.overrides(cmd),
+		Syntax:          f.syntax,
+		Profile:         f.profile,
+		DisableFallback: f.noFallback,
+		Explain:         f.explain,
 	}
 }
 
@@ -79,11 +146,22 @@ func runSearch(cmd *cobra.Command, factory searcherFactory, flags *searchFlags)
 	}
 	defer s.Close()
 
-	if err := s.EnsureIndex(ctx); err != nil {
+	s.SetRefreshPolicy(flags.policy())
+	bar := newProgressBar(cmd.ErrOrStderr())
+	err = s.EnsureIndex(ctx, bar.report())
+	bar.clear()
+	if err != nil {
 		return err
 	}
 
-	results, err := s.Search(ctx, flags.query, flags.options())
+	// 카테고리별 개수는 추가 쿼리가 필요하므로 --facets를 지정했을 때만 셉니다
+	var results []search.SearchResult
+	var facets []search.CategoryFacet
+	if flags.facets {
+		results, facets, err = s.SearchWithFacets(ctx, flags.query, flags.options(cmd))
+	} else {
+		results, err = s.Search(ctx, flags.query, flags.options(cmd))
+	}
 	if err != nil {
 		return err
 	}
@@ -94,5 +172,62 @@ func runSearch(cmd *cobra.Command, factory searcherFactory, flags *searchFlags)
 	}
 
 	fmt.Fprintln(cmd.OutOrStdout(), string(output))
+
+	if flags.explain {
+		printExplanations(cmd.ErrOrStderr(), results)
+	}
+	if flags.facets {
+		printCategoryFacets(cmd.ErrOrStderr(), facets)
+	}
+
+	// 결과가 없거나 완화한 검색으로 찾은 경우 stdout의 JSON은 그대로 두고 교정 제안만 stderr에 출력합니다
+	if len(results) == 0 || results[0].Relaxation != "" {
+		suggestions, err := s.Suggest(ctx, flags.query, search.DefaultSuggestionLimit)
+		if err == nil && len(suggestions) > 0 {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Did you mean: %s\n", strings.Join(suggestions, ", "))
+		}
+	}
 	return nil
 }
+
+// printCategoryFacets는 최상위 카테고리와 그 아래 두 번째 단계 카테고리의 문서 수를 들여써서 출력합니다
+func printCategoryFacets(w io.Writer, facets []search.CategoryFacet) {
+	if len(facets) == 0 {
+		return
+	}
+
+	fmt.Fprintln(w, "Categories:")
+	for _, facet := range facets {
+		fmt.Fprintf(w, "  %s (%d)\n", facet.Category, facet.Count)
+		for _, sub := range facet.Subcategories {
+			fmt.Fprintf(w, "    %s (%d)\n", strings.TrimPrefix(sub.Category, facet.Category+" > "), sub.Count)
+		}
+	}
+}
+
+// printExplanations는 결과마다 필드별 점수와 점수가 높은 단어를 출력합니다.
+// 오타 허용이나 접두어 완화로 매칭된 단어에는 ~를 붙입니다.
+func printExplanations(w io.Writer, results []search.SearchResult) {
+	for i, r := range results {
+		expl := r.Explanation
+		if expl == nil {
+			continue
+		}
+
+		fmt.Fprintf(w, "%d. %s (score %.4f)\n", i+1, r.Title, r.Score)
+		if expl.LinkFactor > 0 {
+			fmt.Fprintf(w, "   fields %.4f × links %.4f (%d backlinks)\n", expl.FieldScore, expl.LinkFactor, expl.Backlinks)
+		}
+		for _, field := range expl.Fields {
+			terms := make([]string, 0, len(field.Terms))
+			for _, term := range field.Terms {
+				marker := ""
+				if term.Fuzzy {
+					marker = "~"
+				}
+				terms = append(terms, fmt.Sprintf("%s%s=%.4f", term.Term, marker, term.Score))
+			}
+			fmt.Fprintf(w, "   %-12s %.4f  %s\n", field.Field, field.Score, strings.Join(terms, " "))
+		}
+	}
+}
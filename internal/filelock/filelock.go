@@ -0,0 +1,73 @@
+// Package filelock은 여러 ax 프로세스가 같은 캐시 디렉터리를 공유할 때 사용하는
+// 권고(advisory) 파일 잠금을 제공합니다.
+package filelock
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// Lock은 잠금 파일 하나에 대한 핸들입니다
+type Lock struct {
+	f *os.File
+}
+
+// errLocked는 TryExclusive에서 다른 프로세스가 잠금을 잡고 있어 바로 획득하지 못했음을 나타냅니다
+var errLocked = errors.New("filelock: already locked")
+
+// Exclusive는 path의 잠금 파일에 배타적 잠금을 획득할 때까지 대기합니다
+func Exclusive(path string) (*Lock, error) {
+	return acquire(path, func(f *os.File) error { return lockFile(f, true) })
+}
+
+// Shared는 path의 잠금 파일에 공유 잠금을 획득할 때까지 대기합니다.
+// 공유 잠금끼리는 서로 막지 않고, 배타적 잠금과만 상호 배제됩니다.
+func Shared(path string) (*Lock, error) {
+	return acquire(path, func(f *os.File) error { return lockFile(f, false) })
+}
+
+// TryExclusive는 path의 잠금 파일에 배타적 잠금을 기다리지 않고 시도합니다.
+// 다른 잠금이 잡혀 있으면 nil, false, nil을 반환합니다.
+func TryExclusive(path string) (*Lock, bool, error) {
+	lock, err := acquire(path, tryLockFile)
+	if errors.Is(err, errLocked) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return lock, true, nil
+}
+
+func acquire(path string, lock func(*os.File) error) (*Lock, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := lock(f); err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	return &Lock{f: f}, nil
+}
+
+// Unlock은 잠금을 해제하고 잠금 파일을 닫습니다.
+// 다른 프로세스가 같은 파일을 기다리고 있을 수 있으므로 잠금 파일은 삭제하지 않습니다.
+func (l *Lock) Unlock() error {
+	if l == nil || l.f == nil {
+		return nil
+	}
+	err := unlockFile(l.f)
+	if cerr := l.f.Close(); err == nil {
+		err = cerr
+	}
+	l.f = nil
+	return err
+}
@@ -41,34 +41,77 @@ func newRetryClient(timeout time.Duration) *http.Client {
 	return rc.StandardClient()
 }
 
-// FetchWithETag는 URL에서 콘텐츠를 가져오고 ETag를 반환합니다.
+// Validators는 조건부 요청에 사용하는 캐시 검증 값입니다
+type Validators struct {
+	ETag         string
+	LastModified string
+}
+
+// FetchResult는 Fetch의 결과입니다.
+// NotModified가 true이면 서버가 304를 반환한 것이며 Content는 비어 있습니다.
+type FetchResult struct {
+	Content      string
+	ETag         string
+	LastModified string
+	NotModified  bool
+}
+
+// Fetch는 URL에서 콘텐츠를 가져옵니다.
+// validators가 주어지면 If-None-Match/If-Modified-Since 조건부 요청을 보냅니다.
 // Exponential backoff로 최대 3회 재시도합니다.
-func FetchWithETag(ctx context.Context, url string, timeout time.Duration) (content string, etag string, err error) {
+func Fetch(ctx context.Context, url string, validators Validators, timeout time.Duration) (*FetchResult, error) {
 	if timeout == 0 {
 		timeout = DefaultTimeout
 	}
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
-		return "", "", &FetchError{URL: url, Err: err}
+		return nil, &FetchError{URL: url, Err: err}
+	}
+	if validators.ETag != "" {
+		req.Header.Set("If-None-Match", validators.ETag)
+	}
+	if validators.LastModified != "" {
+		req.Header.Set("If-Modified-Since", validators.LastModified)
 	}
 
 	resp, err := newRetryClient(timeout).Do(req)
 	if err != nil {
-		return "", "", &FetchError{URL: url, Err: err}
+		return nil, &FetchError{URL: url, Err: err}
 	}
 	defer resp.Body.Close()
 
+	result := &FetchResult{
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+	}
+
+	if resp.StatusCode == http.StatusNotModified {
+		result.NotModified = true
+		return result, nil
+	}
 	if resp.StatusCode != http.StatusOK {
-		return "", "", &FetchError{URL: url, StatusCode: resp.StatusCode}
+		return nil, &FetchError{URL: url, StatusCode: resp.StatusCode}
 	}
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", "", &FetchError{URL: url, Err: err}
+		return nil, &FetchError{URL: url, Err: err}
+	}
+	result.Content = string(body)
+
+	return result, nil
+}
+
+// FetchWithETag는 URL에서 콘텐츠를 가져오고 ETag를 반환합니다.
+// Exponential backoff로 최대 3회 재시도합니다.
+func FetchWithETag(ctx context.Context, url string, timeout time.Duration) (content string, etag string, err error) {
+	result, err := Fetch(ctx, url, Validators{}, timeout)
+	if err != nil {
+		return "", "", err
 	}
 
-	return string(body), resp.Header.Get("ETag"), nil
+	return result.Content, result.ETag, nil
 }
 
 // CheckETag는 URL의 ETag가 변경되었는지 확인합니다.
@@ -46,7 +46,7 @@ func Run() {
 
 	if err := cmd.NewCommand(cfg).ExecuteContext(ctx); err != nil {
 		fmt.Printf("Err: %v\n", err)
-		os.Exit(1)
+		os.Exit(cmd.ExitCode(err))
 	}
 }
 
@@ -0,0 +1,170 @@
+// Package eval은 골든 쿼리 세트로 검색 품질(MRR, nDCG@k, recall@k)을 측정합니다.
+package eval
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+)
+
+// DefaultK는 nDCG와 recall을 계산할 때 보는 기본 순위 범위입니다
+const DefaultK = 10
+
+// GoldenSet은 평가에 사용할 쿼리 목록입니다.
+//
+//	{
+//	  "queries": [
+//	    {"query": "결제 연동", "corpus": "docs", "expected": ["https://developers-apps-in-toss.toss.im/..."]}
+//	  ]
+//	}
+type GoldenSet struct {
+	Queries []GoldenQuery `json:"queries"`
+}
+
+// GoldenQuery는 쿼리 하나와 기대하는 문서 URL 목록입니다
+type GoldenQuery struct {
+	Query    string   `json:"query"`
+	Corpus   string   `json:"corpus,omitempty"` // docs(기본값), tds-rn, tds-web
+	Expected []string `json:"expected"`
+	Syntax   bool     `json:"syntax,omitempty"`
+	Profile  string   `json:"profile,omitempty"` // 지정하면 ax eval --profile보다 우선합니다
+}
+
+// LoadGoldenSet은 JSON 골든 세트 파일을 읽고 검증합니다
+func LoadGoldenSet(path string) (*GoldenSet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read golden set: %w", err)
+	}
+
+	var set GoldenSet
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("failed to parse golden set: %w", err)
+	}
+	if len(set.Queries) == 0 {
+		return nil, fmt.Errorf("golden set %s has no queries", path)
+	}
+	for i, q := range set.Queries {
+		if strings.TrimSpace(q.Query) == "" {
+			return nil, fmt.Errorf("golden set query #%d has an empty query", i+1)
+		}
+		if len(q.Expected) == 0 {
+			return nil, fmt.Errorf("golden set query #%d (%q) has no expected urls", i+1, q.Query)
+		}
+	}
+
+	return &set, nil
+}
+
+// SearchFunc는 쿼리를 실행해 순위대로 정렬된 문서 URL을 반환합니다
+type SearchFunc func(ctx context.Context, q GoldenQuery, limit int) ([]string, error)
+
+// QueryResult는 쿼리 하나의 평가 결과입니다
+type QueryResult struct {
+	Query          string   `json:"query"`
+	Corpus         string   `json:"corpus,omitempty"`
+	ReciprocalRank float64  `json:"reciprocal_rank"`
+	NDCG           float64  `json:"ndcg"`
+	Recall         float64  `json:"recall"`
+	FirstHit       int      `json:"first_hit,omitempty"` // 처음 기대 문서가 나온 순위 (1부터, 없으면 0)
+	Missing        []string `json:"missing,omitempty"`   // 상위 k개에 없는 기대 문서
+	Results        []string `json:"results"`
+}
+
+// Summary는 모든 쿼리의 평균입니다
+type Summary struct {
+	MRR    float64 `json:"mrr"`
+	NDCG   float64 `json:"ndcg"`
+	Recall float64 `json:"recall"`
+}
+
+// Report는 골든 세트 평가 결과입니다
+type Report struct {
+	K       int           `json:"k"`
+	Queries []QueryResult `json:"queries"`
+	Summary Summary       `json:"summary"`
+}
+
+// Run은 골든 세트의 모든 쿼리를 search로 실행하고 상위 k개 결과로 지표를 계산합니다
+func Run(ctx context.Context, set *GoldenSet, k int, search SearchFunc) (*Report, error) {
+	if k <= 0 {
+		k = DefaultK
+	}
+
+	report := &Report{K: k, Queries: make([]QueryResult, 0, len(set.Queries))}
+	for _, q := range set.Queries {
+		ranked, err := search(ctx, q, k)
+		if err != nil {
+			return nil, fmt.Errorf("query %q failed: %w", q.Query, err)
+		}
+		if len(ranked) > k {
+			ranked = ranked[:k]
+		}
+
+		result := score(q, ranked, k)
+		report.Queries = append(report.Queries, result)
+		report.Summary.MRR += result.ReciprocalRank
+		report.Summary.NDCG += result.NDCG
+		report.Summary.Recall += result.Recall
+	}
+
+	n := float64(len(report.Queries))
+	if n > 0 {
+		report.Summary.MRR /= n
+		report.Summary.NDCG /= n
+		report.Summary.Recall /= n
+	}
+	return report, nil
+}
+
+// score는 이진 관련도(기대 문서면 1, 아니면 0)로 지표를 계산합니다
+func score(q GoldenQuery, ranked []string, k int) QueryResult {
+	expected := map[string]bool{}
+	for _, u := range q.Expected {
+		expected[normalizeURL(u)] = true
+	}
+
+	result := QueryResult{Query: q.Query, Corpus: q.Corpus, Results: ranked}
+	found := map[string]bool{}
+	dcg := 0.0
+	for i, u := range ranked {
+		key := normalizeURL(u)
+		if !expected[key] || found[key] {
+			continue
+		}
+		found[key] = true
+		if result.FirstHit == 0 {
+			result.FirstHit = i + 1
+			result.ReciprocalRank = 1 / float64(i+1)
+		}
+		dcg += 1 / math.Log2(float64(i+2))
+	}
+
+	ideal := 0.0
+	for i := 0; i < min(len(expected), k); i++ {
+		ideal += 1 / math.Log2(float64(i+2))
+	}
+	if ideal > 0 {
+		result.NDCG = dcg / ideal
+	}
+	result.Recall = float64(len(found)) / float64(len(expected))
+
+	for _, u := range q.Expected {
+		if !found[normalizeURL(u)] {
+			result.Missing = append(result.Missing, u)
+		}
+	}
+	return result
+}
+
+// normalizeURL은 끝의 /와 프래그먼트 차이를 무시하고 URL을 비교하도록 정리합니다
+func normalizeURL(u string) string {
+	u = strings.TrimSpace(u)
+	if i := strings.Index(u, "#"); i >= 0 {
+		u = u[:i]
+	}
+	return strings.TrimSuffix(u, "/")
+}
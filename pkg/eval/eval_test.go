@@ -0,0 +1,101 @@
+package eval
+
+import (
+	"context"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func almostEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestScore(t *testing.T) {
+	q := GoldenQuery{Query: "결제", Expected: []string{"https://example.com/a", "https://example.com/b/"}}
+
+	tests := []struct {
+		name   string
+		ranked []string
+		rr     float64
+		ndcg   float64
+		recall float64
+	}{
+		{"perfect", []string{"https://example.com/a", "https://example.com/b"}, 1, 1, 1},
+		{"second", []string{"https://example.com/x", "https://example.com/a#top"}, 0.5, (1 / math.Log2(3)) / (1 + 1/math.Log2(3)), 0.5},
+		{"none", []string{"https://example.com/x"}, 0, 0, 0},
+		{"duplicates count once", []string{"https://example.com/a", "https://example.com/a"}, 1, 1 / (1 + 1/math.Log2(3)), 0.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := score(q, tt.ranked, 10)
+			if !almostEqual(got.ReciprocalRank, tt.rr) {
+				t.Errorf("reciprocal rank = %v, want %v", got.ReciprocalRank, tt.rr)
+			}
+			if !almostEqual(got.NDCG, tt.ndcg) {
+				t.Errorf("ndcg = %v, want %v", got.NDCG, tt.ndcg)
+			}
+			if !almostEqual(got.Recall, tt.recall) {
+				t.Errorf("recall = %v, want %v", got.Recall, tt.recall)
+			}
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	set := &GoldenSet{Queries: []GoldenQuery{
+		{Query: "hit", Expected: []string{"https://example.com/a"}},
+		{Query: "miss", Expected: []string{"https://example.com/b"}},
+	}}
+
+	var limits []int
+	search := func(ctx context.Context, q GoldenQuery, limit int) ([]string, error) {
+		limits = append(limits, limit)
+		return []string{"https://example.com/a", "https://example.com/c", "https://example.com/b"}, nil
+	}
+
+	report, err := Run(context.Background(), set, 2, search)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if limits[0] != 2 {
+		t.Errorf("Expected search limit 2, got %d", limits[0])
+	}
+	// miss의 기대 문서는 3위라 k=2 밖이므로 찾지 못한 것으로 계산되어야 함
+	if report.Queries[1].Recall != 0 || len(report.Queries[1].Missing) != 1 {
+		t.Errorf("Expected miss outside top k, got %+v", report.Queries[1])
+	}
+	if !almostEqual(report.Summary.MRR, 0.5) || !almostEqual(report.Summary.Recall, 0.5) {
+		t.Errorf("Unexpected summary: %+v", report.Summary)
+	}
+}
+
+func TestLoadGoldenSet(t *testing.T) {
+	dir := t.TempDir()
+
+	valid := filepath.Join(dir, "golden.json")
+	os.WriteFile(valid, []byte(`{"queries":[{"query":"결제 연동","corpus":"docs","expected":["https://example.com/a"]}]}`), 0644)
+	set, err := LoadGoldenSet(valid)
+	if err != nil {
+		t.Fatalf("LoadGoldenSet failed: %v", err)
+	}
+	if len(set.Queries) != 1 || set.Queries[0].Corpus != "docs" {
+		t.Errorf("Unexpected golden set: %+v", set)
+	}
+
+	invalid := map[string]string{
+		"empty.json":       `{"queries":[]}`,
+		"no-expected.json": `{"queries":[{"query":"결제"}]}`,
+		"no-query.json":    `{"queries":[{"query":" ","expected":["https://example.com/a"]}]}`,
+		"broken.json":      `{"queries":`,
+	}
+	for name, content := range invalid {
+		path := filepath.Join(dir, name)
+		os.WriteFile(path, []byte(content), 0644)
+		if _, err := LoadGoldenSet(path); err == nil {
+			t.Errorf("Expected error for %s", name)
+		}
+	}
+}
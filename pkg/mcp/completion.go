@@ -68,6 +68,11 @@ func (r *CompletionRegistry) Register(c Completion) {
 	r.entries[key][c.Arg] = c.Values
 }
 
+// Values returns all allowed values for an argument, or nil if none are registered.
+func (r *CompletionRegistry) Values(ref CompletionRef, arg string) []string {
+	return r.entries[ref.key()][arg]
+}
+
 // Complete returns values matching the given prefix for an argument.
 func (r *CompletionRegistry) Complete(ref CompletionRef, arg, prefix string) []string {
 	args, ok := r.entries[ref.key()]
@@ -0,0 +1,31 @@
+package mcp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/toss/apps-in-toss-ax/pkg/search"
+)
+
+// 여러 문서 모음을 다루는 도구에서 corpus 인자로 받는 값입니다 (CLI 하위 명령 이름과 같음)
+const (
+	corpusDocs   = "docs"
+	corpusTdsRn  = "tds-rn"
+	corpusTdsWeb = "tds-web"
+)
+
+var corpora = []string{corpusDocs, corpusTdsRn, corpusTdsWeb}
+
+// searcherFor는 corpus에 해당하는 lazySearcher를 반환합니다. 비어 있으면 AppsInToss 문서를 사용합니다.
+func (p *Protocol) searcherFor(corpus string) (*lazySearcher, error) {
+	switch corpus {
+	case "", corpusDocs:
+		return p.docSearcher, nil
+	case corpusTdsRn:
+		return p.tdsRn, nil
+	case corpusTdsWeb:
+		return p.tdsWeb, nil
+	default:
+		return nil, search.InvalidArgument(fmt.Errorf("unknown corpus %q (supported: %s)", corpus, strings.Join(corpora, ", ")))
+	}
+}
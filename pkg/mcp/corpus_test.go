@@ -0,0 +1,30 @@
+package mcp
+
+import "testing"
+
+func TestSearcherFor(t *testing.T) {
+	p := New()
+
+	tests := []struct {
+		corpus string
+		want   *lazySearcher
+	}{
+		{"", p.docSearcher},
+		{corpusDocs, p.docSearcher},
+		{corpusTdsRn, p.tdsRn},
+		{corpusTdsWeb, p.tdsWeb},
+	}
+	for _, tt := range tests {
+		got, err := p.searcherFor(tt.corpus)
+		if err != nil {
+			t.Fatalf("searcherFor(%q) failed: %v", tt.corpus, err)
+		}
+		if got != tt.want {
+			t.Errorf("searcherFor(%q) returned the wrong searcher", tt.corpus)
+		}
+	}
+
+	if _, err := p.searcherFor("unity"); err == nil {
+		t.Error("Expected error for unknown corpus")
+	}
+}
@@ -0,0 +1,124 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/toss/apps-in-toss-ax/pkg/search"
+)
+
+// ToolError는 실패한 도구 호출의 결과입니다. 모델이 코드와 힌트를 보고 다음 호출을 고칠 수 있도록
+// isError 결과의 본문과 structuredContent에 함께 담습니다.
+type ToolError struct {
+	Code    search.ErrorCode `json:"code"`
+	Message string           `json:"message"`
+	Hint    string           `json:"hint,omitempty"`
+}
+
+// searchToolFor는 문서 조회 도구의 ID를 얻을 수 있는 검색 도구입니다
+var searchToolFor = map[string]string{
+	getTdsRnDoc.Name:  searchTdsRnDocs.Name,
+	getTdsWebDoc.Name: searchTdsWebDocs.Name,
+}
+
+// searchToolForCorpus는 corpus 인자를 받는 도구에서 그 코퍼스의 ID를 얻을 수 있는 검색 도구입니다
+var searchToolForCorpus = map[string]string{
+	corpusDocs:   searchDocs.Name,
+	corpusTdsRn:  searchTdsRnDocs.Name,
+	corpusTdsWeb: searchTdsWebDocs.Name,
+}
+
+// newToolError는 tool 호출에서 발생한 err를 분류하고 힌트를 붙입니다. corpus는 호출의 corpus 인자입니다 (없으면 빈 문자열).
+// handlerRan이 false이면 SDK가 핸들러를 부르기 전에(입력 스키마 검증이나 인자 디코딩에서) 실패한 것이므로 잘못된 인자로 분류합니다.
+func newToolError(tool, corpus string, err error, handlerRan bool) ToolError {
+	code := search.CodeOf(err)
+	if !handlerRan && code == search.ErrorInternal {
+		code = search.ErrorInvalidArgument
+	}
+	return ToolError{Code: code, Message: err.Error(), Hint: errorHint(tool, corpus, code, err)}
+}
+
+// handlerRanKey는 toolErrorMiddleware가 도구 핸들러의 실행 여부를 받는 context 키입니다
+type handlerRanKey struct{}
+
+// addTool은 mcp.AddTool과 같고, 핸들러가 실행되면 toolErrorMiddleware에 알립니다
+func addTool[In, Out any](server *mcp.Server, tool *mcp.Tool, handler mcp.ToolHandlerFor[In, Out]) {
+	mcp.AddTool(server, tool, func(ctx context.Context, req *mcp.CallToolRequest, input In) (*mcp.CallToolResult, Out, error) {
+		if ran, ok := ctx.Value(handlerRanKey{}).(*bool); ok {
+			*ran = true
+		}
+		return handler(ctx, req, input)
+	})
+}
+
+func errorHint(tool, corpus string, code search.ErrorCode, err error) string {
+	var syntaxErr *search.QuerySyntaxError
+	switch code {
+	case search.ErrorNotFound:
+		if errors.Is(err, search.ErrSectionNotFound) {
+			return "Call get_doc_outline with the same id to list the section headings and anchors."
+		}
+		searchTool := searchToolFor[tool]
+		if searchTool == "" {
+			searchTool = searchToolForCorpus[corpus]
+		}
+		if searchTool == "" {
+			searchTool = searchDocs.Name
+		}
+		return fmt.Sprintf("Run %s first and use an id from its results (with the matching corpus); IDs cannot be derived from titles or URLs.", searchTool)
+	case search.ErrorInvalidArgument:
+		if errors.As(err, &syntaxErr) {
+			return "Fix the query at the reported position, or call again without syntax=true."
+		}
+		return "Correct the argument named in the message and call the tool again; the input schema lists the valid values."
+	case search.ErrorIndexUnavailable:
+		if errors.Is(err, errIndexWarming) {
+			return "The index is being built in the background (the first run downloads the documentation). Retry in a few seconds; index_status shows the progress of each corpus."
+		}
+		return "The documentation index could not be prepared. Retry in a moment; if it keeps failing, ask the user to run `ax search docs --query test --refresh` in a terminal."
+	case search.ErrorNetwork:
+		return "The documentation server could not be reached and no cached index is available. Retry later or ask the user to check the network connection."
+	default:
+		return ""
+	}
+}
+
+// text는 structuredContent를 읽지 않는 클라이언트를 위한 본문입니다
+func (e ToolError) text() string {
+	text := fmt.Sprintf("Error [%s]: %s", e.Code, e.Message)
+	if e.Hint != "" {
+		text += "\nHint: " + e.Hint
+	}
+	return text
+}
+
+// toolErrorMiddleware는 핸들러가 반환한 에러로 SDK가 만든 isError 결과에 에러 코드와 힌트를 채웁니다
+func (p *Protocol) toolErrorMiddleware() mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			if method != "tools/call" {
+				return next(ctx, method, req)
+			}
+
+			handlerRan := false
+			result, err := next(context.WithValue(ctx, handlerRanKey{}, &handlerRan), method, req)
+			if err != nil {
+				return result, err
+			}
+
+			toolResult, ok := result.(*mcp.CallToolResult)
+			if !ok || toolResult == nil || !toolResult.IsError || toolResult.GetError() == nil {
+				return result, nil
+			}
+
+			tool, _ := toolCallInfo(req.GetParams())
+			corpus := toolCallArgument(req.GetParams(), "corpus")
+			toolErr := newToolError(tool, corpus, toolResult.GetError(), handlerRan)
+			toolResult.Content = []mcp.Content{&mcp.TextContent{Text: toolErr.text()}}
+			toolResult.StructuredContent = toolErr
+			return toolResult, nil
+		}
+	}
+}
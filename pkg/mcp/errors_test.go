@@ -0,0 +1,121 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/toss/apps-in-toss-ax/pkg/search"
+)
+
+func TestNewToolError_Hints(t *testing.T) {
+	tests := []struct {
+		tool       string
+		corpus     string
+		err        error
+		handlerRan bool
+		code       search.ErrorCode
+		hintPart   string
+	}{
+		{getTdsRnDoc.Name, "", search.ErrDocumentNotFound, true, search.ErrorNotFound, "search_tds_rn_docs"},
+		{findInDoc.Name, corpusTdsWeb, search.ErrDocumentNotFound, true, search.ErrorNotFound, "search_tds_web_docs"},
+		{getRelatedDocs.Name, corpusTdsRn, search.ErrDocumentNotFound, true, search.ErrorNotFound, "search_tds_rn_docs"},
+		{getDocOutline.Name, "", search.ErrDocumentNotFound, true, search.ErrorNotFound, "search_docs"},
+		{getDoc.Name, "", search.ErrSectionNotFound, true, search.ErrorNotFound, "get_doc_outline"},
+		{searchDocs.Name, "", &search.QuerySyntaxError{Message: "unterminated phrase"}, true, search.ErrorInvalidArgument, "syntax=true"},
+		{searchDocs.Name, "", errors.New(`missing properties: ["query"]`), false, search.ErrorInvalidArgument, "input schema"},
+		{searchDocs.Name, "", errors.New("unexpected"), true, search.ErrorInternal, ""},
+		{searchDocs.Name, "", search.IndexUnavailable(errors.New("corrupted")), true, search.ErrorIndexUnavailable, "--refresh"},
+		{searchDocs.Name, "", search.IndexUnavailable(errIndexWarming), true, search.ErrorIndexUnavailable, "index_status"},
+	}
+	for _, tt := range tests {
+		got := newToolError(tt.tool, tt.corpus, tt.err, tt.handlerRan)
+		if got.Code != tt.code || !strings.Contains(got.Hint, tt.hintPart) {
+			t.Errorf("newToolError(%s, %q, %v, %v) = %+v, want code %q and a hint mentioning %q", tt.tool, tt.corpus, tt.err, tt.handlerRan, got, tt.code, tt.hintPart)
+		}
+	}
+}
+
+func TestToolErrorMiddleware_IsErrorResult(t *testing.T) {
+	p := New()
+	p.OnInit = func(context.Context) {}
+	p.docSearcher = newLazySearcher(fakeSearcher)
+
+	ctx := context.Background()
+	serverTransport, clientTransport := mcpsdk.NewInMemoryTransports()
+	serverSession, err := p.Server.Connect(ctx, serverTransport, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer serverSession.Close()
+
+	client := mcpsdk.NewClient(&mcpsdk.Implementation{Name: "test", Version: "0.0.0"}, nil)
+	session, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer session.Close()
+
+	result, err := session.CallTool(ctx, &mcpsdk.CallToolParams{Name: getDoc.Name, Arguments: map[string]any{"id": "missing"}})
+	if err != nil {
+		t.Fatalf("Expected a tool result rather than a protocol error, got %v", err)
+	}
+	if !result.IsError {
+		t.Fatal("Expected isError for a missing document")
+	}
+
+	text := result.Content[0].(*mcpsdk.TextContent).Text
+	if !strings.HasPrefix(text, "Error [not_found]: document not found: missing") || !strings.Contains(text, "Hint: Run search_docs first") {
+		t.Errorf("Unexpected error text %q", text)
+	}
+
+	data, err := json.Marshal(result.StructuredContent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var toolErr ToolError
+	if err := json.Unmarshal(data, &toolErr); err != nil || toolErr.Code != search.ErrorNotFound {
+		t.Errorf("Expected structured error with code not_found, got %s", data)
+	}
+}
+
+func TestToolErrorMiddleware_InvalidArgumentsBeforeHandler(t *testing.T) {
+	p := New()
+	p.OnInit = func(context.Context) {}
+	p.docSearcher = newLazySearcher(fakeSearcher)
+
+	ctx := context.Background()
+	serverTransport, clientTransport := mcpsdk.NewInMemoryTransports()
+	serverSession, err := p.Server.Connect(ctx, serverTransport, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer serverSession.Close()
+
+	client := mcpsdk.NewClient(&mcpsdk.Implementation{Name: "test", Version: "0.0.0"}, nil)
+	session, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer session.Close()
+
+	tests := map[string]map[string]any{
+		"missing required argument": {},
+		"wrong argument type":       {"id": 42},
+	}
+	for name, args := range tests {
+		t.Run(name, func(t *testing.T) {
+			result, err := session.CallTool(ctx, &mcpsdk.CallToolParams{Name: getDoc.Name, Arguments: args})
+			if err != nil {
+				t.Fatalf("Expected a tool result rather than a protocol error, got %v", err)
+			}
+			text := result.Content[0].(*mcpsdk.TextContent).Text
+			if !result.IsError || !strings.HasPrefix(text, "Error [invalid_argument]") {
+				t.Errorf("Expected an invalid_argument result, got %q", text)
+			}
+		})
+	}
+}
@@ -0,0 +1,333 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/toss/apps-in-toss-ax/pkg/search"
+)
+
+// indexState는 index_status 도구가 보고하는 인덱스 준비 상태입니다
+type indexState string
+
+const (
+	// indexIdle은 아직 준비를 시작하지 않은 상태입니다
+	indexIdle indexState = "idle"
+	// indexWarming은 원본을 확인하거나 내려받아 색인하는 중인 상태입니다
+	indexWarming indexState = "warming"
+	indexReady   indexState = "ready"
+	// indexFailed는 마지막 준비가 실패한 상태입니다. 다음 호출에서 다시 준비합니다.
+	indexFailed indexState = "failed"
+)
+
+// warmupWait는 get이 진행 중인 준비를 기다리는 최대 시간입니다. 캐시된 인덱스를 여는 정도는 기다리고,
+// 처음 내려받아 색인하는 것처럼 오래 걸리면 도구 호출이 멈춰 있지 않도록 바로 실패합니다.
+// 테스트에서 줄일 수 있도록 변수로 둡니다.
+var warmupWait = 2 * time.Second
+
+// refreshCheckInterval은 준비된 인덱스의 원본을 확인할 차례인지(NeedsRefresh) 다시 살펴보는 간격입니다.
+// 오래 실행되는 서버도 FreshnessTTL이 지나면 새 원본으로 교체하도록 get에서 확인하며,
+// 메타데이터 파일을 매번 읽지 않도록 간격을 둡니다. 테스트에서 줄일 수 있도록 변수로 둡니다.
+var refreshCheckInterval = time.Minute
+
+// errIndexWarming은 인덱스를 준비하는 중이라 아직 사용할 수 없음을 나타냅니다
+var errIndexWarming = errors.New("search index is still warming up")
+
+// lazySearcher는 Searcher를 처음 필요할 때(또는 warm으로 미리) 백그라운드에서 준비합니다.
+// 준비하는 동안은 디스크에 남은 이전 인덱스로 응답하고, 이전 인덱스가 없으면 warmupWait만큼 기다린 뒤
+// errIndexWarming으로 실패합니다.
+type lazySearcher struct {
+	mu     sync.Mutex
+	s      *search.Searcher
+	initFn func() (*search.Searcher, error)
+
+	// stale은 원본을 확인하는 동안 대신 사용하는 이전 인덱스입니다
+	stale *search.Searcher
+	state indexState
+	err   error
+	// checkedAt은 준비된 인덱스의 NeedsRefresh를 마지막으로 확인한 시각입니다
+	checkedAt time.Time
+	// progress는 진행 중인 준비가 마지막으로 알린 진행 상황이고, progressStep은 알린 횟수입니다
+	progress     search.Progress
+	progressStep int
+	// changed는 상태나 진행 상황이 바뀔 때마다 닫고 새로 만듭니다
+	changed chan struct{}
+
+	// users는 get으로 내준 뒤 아직 release하지 않은 호출 수입니다.
+	// 새 인덱스로 교체된 Searcher는 retired에 두었다가 마지막 호출이 release할 때 닫습니다.
+	users   map[*search.Searcher]int
+	retired map[*search.Searcher]bool
+
+	// log가 nil이 아니면 준비 과정과 실패를 로그로 남깁니다
+	log func(level mcp.LoggingLevel, message string)
+}
+
+func newLazySearcher(initFn func() (*search.Searcher, error)) *lazySearcher {
+	return &lazySearcher{
+		initFn:  initFn,
+		state:   indexIdle,
+		changed: make(chan struct{}),
+		users:   map[*search.Searcher]int{},
+		retired: map[*search.Searcher]bool{},
+	}
+}
+
+// warm은 인덱스 준비를 백그라운드에서 시작하고 바로 반환합니다.
+// 이미 준비 중이면 아무것도 하지 않고, 준비된 경우에는 원본을 확인할 차례일 때만 새로 준비합니다.
+func (ls *lazySearcher) warm(ctx context.Context) {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+	ls.warmLocked(ctx)
+}
+
+func (ls *lazySearcher) warmLocked(ctx context.Context) {
+	switch ls.state {
+	case indexWarming:
+		return
+	case indexReady:
+		ls.refreshLocked(ctx)
+		return
+	}
+	ls.state = indexWarming
+	ls.err = nil
+	ls.progress = search.Progress{}
+	ls.notifyLocked()
+	// 준비는 요청보다 오래 걸릴 수 있으므로 요청이 끝나도 취소하지 않습니다
+	go ls.run(context.WithoutCancel(ctx))
+}
+
+// refreshLocked는 준비된 인덱스의 원본을 확인할 차례이면 그 인덱스로 계속 응답하면서 백그라운드에서 새로 준비합니다
+func (ls *lazySearcher) refreshLocked(ctx context.Context) {
+	if ls.s == nil || time.Since(ls.checkedAt) < refreshCheckInterval {
+		return
+	}
+	ls.checkedAt = time.Now()
+	if !ls.s.NeedsRefresh() {
+		return
+	}
+
+	old := ls.s
+	ls.s = nil
+	ls.stale = old
+	ls.state = indexWarming
+	ls.err = nil
+	ls.progress = search.Progress{}
+	ls.notifyLocked()
+	go ls.refresh(context.WithoutCancel(ctx), old)
+}
+
+func (ls *lazySearcher) notifyLocked() {
+	close(ls.changed)
+	ls.changed = make(chan struct{})
+}
+
+// run은 인덱스를 준비합니다. 디스크에 최근에 확인한 인덱스가 있으면 그대로 열고,
+// 원본을 확인할 때가 되었으면 이전 인덱스로 응답하면서 새 Searcher로 확인과 재색인을 진행한 뒤 교체합니다.
+func (ls *lazySearcher) run(ctx context.Context) {
+	s, err := ls.initFn()
+	if err != nil {
+		ls.logf("error", "search index unavailable: %v", err)
+		ls.finish(nil, err)
+		return
+	}
+
+	if err := s.OpenCachedIndex(); err != nil {
+		if err := s.EnsureIndex(ctx, ls.report); err != nil {
+			s.Close()
+			ls.logf("error", "search index unavailable: %v", err)
+			ls.finish(nil, err)
+			return
+		}
+		ls.finish(s, nil)
+		return
+	}
+	if !s.NeedsRefresh() {
+		ls.finish(s, nil)
+		return
+	}
+
+	ls.setStale(s)
+	ls.refresh(ctx, s)
+}
+
+// refresh는 old로 응답하는 동안 새 Searcher로 원본 확인과 재색인을 진행한 뒤 교체합니다.
+// 실패하면 old를 계속 사용합니다.
+func (ls *lazySearcher) refresh(ctx context.Context, s *search.Searcher) {
+	fresh, err := ls.initFn()
+	if err == nil {
+		if err = fresh.EnsureIndex(ctx, ls.report); err != nil {
+			fresh.Close()
+		}
+	}
+	if err != nil {
+		// 새로 준비하지 못했으면 이전 인덱스를 계속 사용하고 에러는 index_status로 알립니다
+		err = fmt.Errorf("refresh failed, using the previous index: %w", err)
+		ls.logf("warning", "%v", err)
+		ls.finish(s, err)
+		return
+	}
+	ls.finish(fresh, nil)
+	ls.retire(s)
+}
+
+// retire는 교체된 Searcher를 사용하는 호출이 없으면 바로 닫고, 있으면 마지막 호출이 release할 때 닫습니다
+func (ls *lazySearcher) retire(s *search.Searcher) {
+	ls.mu.Lock()
+	if ls.users[s] > 0 {
+		ls.retired[s] = true
+		ls.mu.Unlock()
+		return
+	}
+	ls.mu.Unlock()
+	s.Close()
+}
+
+// acquireLocked는 s를 사용하는 호출을 하나 늘리고, 사용이 끝나면 부를 release 함수를 반환합니다
+func (ls *lazySearcher) acquireLocked(s *search.Searcher) func() {
+	ls.users[s]++
+	var once sync.Once
+	return func() {
+		once.Do(func() { ls.release(s) })
+	}
+}
+
+func (ls *lazySearcher) release(s *search.Searcher) {
+	ls.mu.Lock()
+	ls.users[s]--
+	if ls.users[s] > 0 {
+		ls.mu.Unlock()
+		return
+	}
+	delete(ls.users, s)
+	closing := ls.retired[s]
+	delete(ls.retired, s)
+	ls.mu.Unlock()
+
+	if closing {
+		s.Close()
+	}
+}
+
+// report는 EnsureIndex의 진행 상황을 기록해 기다리는 호출에 알리고 로그로 남깁니다
+func (ls *lazySearcher) report(progress search.Progress) {
+	ls.mu.Lock()
+	ls.progress = progress
+	ls.progressStep++
+	ls.notifyLocked()
+	ls.mu.Unlock()
+
+	ls.logf("info", "%s", progress.Message)
+}
+
+func (ls *lazySearcher) logf(level mcp.LoggingLevel, format string, args ...any) {
+	if ls.log != nil {
+		ls.log(level, fmt.Sprintf(format, args...))
+	}
+}
+
+func (ls *lazySearcher) setStale(s *search.Searcher) {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+	ls.stale = s
+	ls.notifyLocked()
+}
+
+// finish는 준비 결과를 기록합니다. s가 nil이면 실패한 것입니다.
+func (ls *lazySearcher) finish(s *search.Searcher, err error) {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+	ls.s = s
+	ls.stale = nil
+	ls.err = err
+	if s != nil {
+		ls.state = indexReady
+		ls.checkedAt = time.Now()
+	} else {
+		ls.state = indexFailed
+	}
+	ls.notifyLocked()
+}
+
+// get은 준비된 Searcher를 반환합니다. 준비를 시작하지 않았거나 마지막 준비가 실패했으면 다시 시작하고,
+// 준비된 인덱스의 원본을 확인할 차례이면 그 인덱스로 응답하면서 새로 준비하며,
+// 준비 중이면 이전 인덱스를 반환하거나 warmupWait까지 기다립니다. ctx에 progressReporter가 있으면
+// 호출자가 진행 상황을 받을 수 있으므로 준비가 끝날 때까지 기다리며 진행 상황을 알립니다.
+// 에러가 없으면 Searcher 사용이 끝났을 때 부를 release 함수도 반환합니다. release하기 전에는
+// 새 인덱스로 교체되더라도 반환한 Searcher를 닫지 않습니다.
+func (ls *lazySearcher) get(ctx context.Context) (*search.Searcher, func(), error) {
+	report := progressReporterFrom(ctx)
+	var timeout <-chan time.Time
+	if report == nil {
+		timer := time.NewTimer(warmupWait)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	reported := 0
+	ls.mu.Lock()
+	ls.warmLocked(ctx)
+	for {
+		switch {
+		case ls.s != nil:
+			s := ls.s
+			release := ls.acquireLocked(s)
+			ls.mu.Unlock()
+			return s, release, nil
+		case ls.stale != nil:
+			s := ls.stale
+			release := ls.acquireLocked(s)
+			ls.mu.Unlock()
+			return s, release, nil
+		case ls.state == indexFailed:
+			err := ls.err
+			ls.mu.Unlock()
+			return nil, nil, err
+		}
+		changed := ls.changed
+		step, progress := ls.progressStep, ls.progress
+		ls.mu.Unlock()
+
+		if report != nil && step > reported {
+			report(step, progress)
+			reported = step
+		}
+
+		select {
+		case <-changed:
+		case <-timeout:
+			return nil, nil, search.IndexUnavailable(errIndexWarming)
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		}
+		ls.mu.Lock()
+	}
+}
+
+// status는 index_status 도구에 보고할 준비 상태입니다. Corpus는 호출자가 채웁니다.
+func (ls *lazySearcher) status() CorpusIndexStatus {
+	ls.mu.Lock()
+	s, stale, state, err := ls.s, ls.stale, ls.state, ls.err
+	ls.mu.Unlock()
+
+	status := CorpusIndexStatus{State: string(state)}
+	if err != nil {
+		status.Error = err.Error()
+	}
+	if s == nil && stale != nil {
+		s = stale
+		status.ServingPreviousIndex = true
+	}
+	if s != nil {
+		if count, err := s.DocCount(); err == nil {
+			status.Documents = int(count)
+		}
+		if t := s.LastRefresh(); !t.IsZero() {
+			status.LastRefresh = t.Format(time.RFC3339)
+		}
+	}
+	return status
+}
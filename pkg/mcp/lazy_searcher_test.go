@@ -6,7 +6,9 @@ import (
 	"sync"
 	"sync/atomic"
 	"testing"
+	"time"
 
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/toss/apps-in-toss-ax/pkg/search"
 )
 
@@ -25,12 +27,12 @@ func TestLazySearcher_SingleInit(t *testing.T) {
 
 	ctx := context.Background()
 
-	s1, err := ls.get(ctx)
+	s1, _, err := ls.get(ctx)
 	if err != nil {
 		t.Fatalf("First get failed: %v", err)
 	}
 
-	s2, err := ls.get(ctx)
+	s2, _, err := ls.get(ctx)
 	if err != nil {
 		t.Fatalf("Second get failed: %v", err)
 	}
@@ -63,7 +65,7 @@ func TestLazySearcher_ConcurrentGet(t *testing.T) {
 		wg.Add(1)
 		go func(idx int) {
 			defer wg.Done()
-			results[idx], errs[idx] = ls.get(ctx)
+			results[idx], _, errs[idx] = ls.get(ctx)
 		}(i)
 	}
 
@@ -103,18 +105,18 @@ func TestLazySearcher_RetryOnError(t *testing.T) {
 	ctx := context.Background()
 
 	// 첫 번째, 두 번째 호출은 실패해야 함
-	_, err := ls.get(ctx)
+	_, _, err := ls.get(ctx)
 	if err == nil {
 		t.Fatal("Expected error on first call")
 	}
 
-	_, err = ls.get(ctx)
+	_, _, err = ls.get(ctx)
 	if err == nil {
 		t.Fatal("Expected error on second call")
 	}
 
 	// 세 번째 호출은 성공해야 함 (재시도 가능)
-	s, err := ls.get(ctx)
+	s, _, err := ls.get(ctx)
 	if err != nil {
 		t.Fatalf("Expected success on third call, got: %v", err)
 	}
@@ -123,7 +125,7 @@ func TestLazySearcher_RetryOnError(t *testing.T) {
 	}
 
 	// 이후 호출은 캐시된 인스턴스 반환
-	s2, err := ls.get(ctx)
+	s2, _, err := ls.get(ctx)
 	if err != nil {
 		t.Fatalf("Expected cached success, got: %v", err)
 	}
@@ -150,7 +152,7 @@ func TestLazySearcher_ConcurrentGetWithInitialFailure(t *testing.T) {
 	ctx := context.Background()
 
 	// 첫 번째 호출 실패
-	_, err := ls.get(ctx)
+	_, _, err := ls.get(ctx)
 	if err == nil {
 		t.Fatal("Expected error on first call")
 	}
@@ -165,7 +167,7 @@ func TestLazySearcher_ConcurrentGetWithInitialFailure(t *testing.T) {
 		wg.Add(1)
 		go func(idx int) {
 			defer wg.Done()
-			results[idx], errs[idx] = ls.get(ctx)
+			results[idx], _, errs[idx] = ls.get(ctx)
 		}(i)
 	}
 
@@ -186,3 +188,217 @@ func TestLazySearcher_ConcurrentGetWithInitialFailure(t *testing.T) {
 		}
 	}
 }
+
+func TestLazySearcher_FailsFastWhileWarming(t *testing.T) {
+	defer func(wait time.Duration) { warmupWait = wait }(warmupWait)
+	warmupWait = 10 * time.Millisecond
+
+	release := make(chan struct{})
+	ls := newLazySearcher(func() (*search.Searcher, error) {
+		<-release
+		return fakeSearcher()
+	})
+
+	ls.warm(context.Background())
+	if got := ls.status().State; got != string(indexWarming) {
+		t.Errorf("Expected warming after warm, got %q", got)
+	}
+
+	_, _, err := ls.get(context.Background())
+	if !errors.Is(err, errIndexWarming) || search.CodeOf(err) != search.ErrorIndexUnavailable {
+		t.Fatalf("Expected errIndexWarming classified as index_unavailable, got %v", err)
+	}
+
+	close(release)
+	warmupWait = 5 * time.Second
+	if _, _, err := ls.get(context.Background()); err != nil {
+		t.Fatalf("Expected the searcher once warm-up finished, got %v", err)
+	}
+	status := ls.status()
+	if status.State != string(indexReady) || status.LastRefresh == "" || status.Error != "" {
+		t.Errorf("Unexpected status after warm-up: %+v", status)
+	}
+}
+
+func TestLazySearcher_StatusAfterFailure(t *testing.T) {
+	ls := newLazySearcher(func() (*search.Searcher, error) {
+		return nil, errors.New("offline")
+	})
+
+	if got := ls.status().State; got != string(indexIdle) {
+		t.Errorf("Expected idle before warm-up, got %q", got)
+	}
+	if _, _, err := ls.get(context.Background()); err == nil {
+		t.Fatal("Expected error")
+	}
+	status := ls.status()
+	if status.State != string(indexFailed) || status.Error != "offline" {
+		t.Errorf("Unexpected status after failure: %+v", status)
+	}
+}
+
+func TestIndexStatusHandler(t *testing.T) {
+	p := &Protocol{
+		docSearcher: newLazySearcher(fakeSearcher),
+		tdsRn:       newLazySearcher(fakeSearcher),
+		tdsWeb:      newLazySearcher(fakeSearcher),
+	}
+	if _, _, err := p.docSearcher.get(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	_, output, err := p.indexStatusHandler(context.Background(), nil, IndexStatusInput{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(output.Corpora) != len(corpora) {
+		t.Fatalf("Expected %d corpora, got %+v", len(corpora), output.Corpora)
+	}
+	if got := output.Corpora[0]; got.Corpus != corpusDocs || got.State != string(indexReady) {
+		t.Errorf("Unexpected docs status: %+v", got)
+	}
+	if got := output.Corpora[1]; got.Corpus != corpusTdsRn || got.State != string(indexIdle) {
+		t.Errorf("Unexpected tds-rn status: %+v", got)
+	}
+}
+
+func TestNew_WarmsIndexesOnInitialized(t *testing.T) {
+	p := New()
+	initialized := make(chan struct{})
+	p.OnInit = func(context.Context) { close(initialized) }
+
+	ctx := context.Background()
+	serverTransport, clientTransport := mcpsdk.NewInMemoryTransports()
+	serverSession, err := p.Server.Connect(ctx, serverTransport, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer serverSession.Close()
+
+	client := mcpsdk.NewClient(&mcpsdk.Implementation{Name: "test", Version: "0.0.0"}, nil)
+	session, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer session.Close()
+
+	select {
+	case <-initialized:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected OnInit after the client's initialized notification")
+	}
+
+	if session.InitializeResult().Capabilities.Logging == nil {
+		t.Error("Expected the logging capability to be declared")
+	}
+}
+
+func TestLazySearcher_ReportsProgressWhileWaiting(t *testing.T) {
+	defer func(wait time.Duration) { warmupWait = wait }(warmupWait)
+	warmupWait = time.Millisecond
+
+	release := make(chan struct{})
+	ls := newLazySearcher(func() (*search.Searcher, error) {
+		<-release
+		return fakeSearcher()
+	})
+	var logged []string
+	var logMu sync.Mutex
+	ls.log = func(level mcpsdk.LoggingLevel, message string) {
+		logMu.Lock()
+		defer logMu.Unlock()
+		logged = append(logged, string(level)+": "+message)
+	}
+
+	reported := make(chan search.Progress, 1)
+	ctx := withProgressReporter(context.Background(), func(step int, progress search.Progress) {
+		reported <- progress
+	})
+
+	ls.warm(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		_, _, err := ls.get(ctx)
+		done <- err
+	}()
+
+	ls.report(search.Progress{Stage: search.StageIndexing, Message: "indexed 100/200 documents", Current: 100, Total: 200})
+	select {
+	case got := <-reported:
+		if got.Current != 100 || got.Total != 200 {
+			t.Errorf("Unexpected progress %+v", got)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected progress to be reported to the waiting call")
+	}
+
+	// progress를 받는 호출은 warmupWait가 지나도 준비가 끝날 때까지 기다려야 함
+	close(release)
+	if err := <-done; err != nil {
+		t.Fatalf("Expected the call to wait for the warm-up, got %v", err)
+	}
+
+	logMu.Lock()
+	defer logMu.Unlock()
+	if len(logged) == 0 || logged[0] != "info: indexed 100/200 documents" {
+		t.Errorf("Unexpected log entries %q", logged)
+	}
+}
+
+func TestLazySearcher_RefreshesWhenReadyIndexIsDue(t *testing.T) {
+	defer func(interval time.Duration) { refreshCheckInterval = interval }(refreshCheckInterval)
+	refreshCheckInterval = 0
+
+	var callCount atomic.Int32
+	ls := newLazySearcher(func() (*search.Searcher, error) {
+		callCount.Add(1)
+		return fakeSearcher()
+	})
+
+	ctx := context.Background()
+	first, release, err := ls.get(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// FreshnessTTL이 지난 것처럼 원본 확인을 강제합니다
+	first.SetRefreshPolicy(search.RefreshPolicy{Force: true})
+	during, releaseDuring, err := ls.get(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	releaseDuring()
+	if during != first {
+		t.Error("Expected the previous index while refreshing")
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for ls.status().State != string(indexReady) && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	after, _, err := ls.get(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if after == first || callCount.Load() != 2 {
+		t.Errorf("Expected a refreshed searcher, initFn called %d times", callCount.Load())
+	}
+
+	// 교체된 Searcher는 get으로 받은 호출이 모두 release할 때까지 닫지 않습니다
+	ls.mu.Lock()
+	pending := ls.retired[first]
+	ls.mu.Unlock()
+	if !pending {
+		t.Error("Expected the replaced searcher to wait for its last user")
+	}
+	if _, err := first.GetDocument(ctx, "missing"); err != nil {
+		t.Errorf("Expected the retired searcher to stay open while in use, got %v", err)
+	}
+	release()
+	ls.mu.Lock()
+	retired, users := len(ls.retired), ls.users[first]
+	ls.mu.Unlock()
+	if retired != 0 || users != 0 {
+		t.Errorf("Expected the retired searcher to be closed after the last release, retired=%d users=%d", retired, users)
+	}
+}
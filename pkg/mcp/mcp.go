@@ -7,7 +7,6 @@ import (
 	"encoding/json"
 	"reflect"
 	"strconv"
-	"sync"
 	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
@@ -23,35 +22,6 @@ const (
 	defaultVersion = "0.0.0-dev"
 )
 
-type lazySearcher struct {
-	mu     sync.Mutex
-	s      *search.Searcher
-	initFn func() (*search.Searcher, error)
-}
-
-func newLazySearcher(initFn func() (*search.Searcher, error)) *lazySearcher {
-	return &lazySearcher{initFn: initFn}
-}
-
-func (ls *lazySearcher) get(ctx context.Context) (*search.Searcher, error) {
-	ls.mu.Lock()
-	defer ls.mu.Unlock()
-
-	if ls.s != nil {
-		return ls.s, nil
-	}
-
-	s, err := ls.initFn()
-	if err != nil {
-		return nil, err
-	}
-	if err := s.EnsureIndex(ctx); err != nil {
-		return nil, err
-	}
-	ls.s = s
-	return ls.s, nil
-}
-
 type Protocol struct {
 	OnInit    func(context.Context)
 	Transport mcp.Transport
@@ -61,6 +31,8 @@ type Protocol struct {
 	docSearcher *lazySearcher
 	tdsRn       *lazySearcher
 	tdsWeb      *lazySearcher
+	projects    projectCache
+	prompts     []*PromptTemplate
 	analytics   *instrumentation.Analytics
 	sessionID   string
 	version     string
@@ -86,10 +58,16 @@ func WithVersion(version string) Option {
 	}
 }
 
+// WithPrompts는 기본 prompt에 더해 등록할 prompt를 지정합니다. 이름이 같은 기본 prompt는 대체됩니다.
+func WithPrompts(templates ...*PromptTemplate) Option {
+	return func(s *Protocol) {
+		s.prompts = append(s.prompts, templates...)
+	}
+}
+
 func New(options ...Option) *Protocol {
 	p := &Protocol{
 		Transport:   &mcp.StdioTransport{},
-		OnInit:      func(_ context.Context) {},
 		completions: NewCompletionRegistry(),
 		docSearcher: newLazySearcher(search.New),
 		tdsRn:       newLazySearcher(search.NewTDSSearcher),
@@ -98,10 +76,18 @@ func New(options ...Option) *Protocol {
 		version:     defaultVersion,
 	}
 
+	p.OnInit = p.warmIndexes
+
 	for _, o := range options {
 		o(p)
 	}
 
+	for _, corpus := range corpora {
+		if ls, err := p.searcherFor(corpus); err == nil && ls != nil {
+			ls.log = p.indexLogger(corpus)
+		}
+	}
+
 	i := mcp.NewServer(
 		&mcp.Implementation{
 			Name:    name,
@@ -114,18 +100,33 @@ func New(options ...Option) *Protocol {
 			HasResources:      true,
 			HasTools:          true,
 			CompletionHandler: p.completions.Handler,
+			// 인덱스를 준비하는 과정을 notifications/message로 알립니다
+			Capabilities: &mcp.ServerCapabilities{Logging: &mcp.LoggingCapabilities{}},
+			InitializedHandler: func(ctx context.Context, _ *mcp.InitializedRequest) {
+				p.OnInit(ctx)
+			},
+			RootsListChangedHandler: p.rootsListChanged,
 		})
-	i.AddReceivingMiddleware(p.analyticsMiddleware())
-
-	i.AddPrompt(miniappActionPlan, miniappActionPlanHandler)
-	p.completions.RegisterAll(miniappActionPlanCompletions)
-
-	mcp.AddTool(i, searchDocs, p.searchDocsHandler)
-	mcp.AddTool(i, searchTdsRnDocs, p.searchTdsRnDocsHandler)
-	mcp.AddTool(i, searchTdsWebDocs, p.searchTdsWebDocsHandler)
-	mcp.AddTool(i, getDoc, p.getDocHandler)
-	mcp.AddTool(i, getTdsRnDoc, p.getTdsRnDocHandler)
-	mcp.AddTool(i, getTdsWebDoc, p.getTdsWebDocHandler)
+	i.AddReceivingMiddleware(p.analyticsMiddleware(), p.toolErrorMiddleware(), p.progressMiddleware())
+
+	p.registerPrompts(i)
+
+	addTool(i, searchDocs, p.searchDocsHandler)
+	addTool(i, searchTdsRnDocs, p.searchTdsRnDocsHandler)
+	addTool(i, searchTdsWebDocs, p.searchTdsWebDocsHandler)
+	addTool(i, getDoc, p.getDocHandler)
+	addTool(i, getTdsRnDoc, p.getTdsRnDocHandler)
+	addTool(i, getTdsWebDoc, p.getTdsWebDocHandler)
+	addTool(i, getDocs, p.getDocsHandler)
+	addTool(i, getDocOutline, p.getDocOutlineHandler)
+	addTool(i, findInDoc, p.findInDocHandler)
+	addTool(i, getRelatedDocs, p.getRelatedDocsHandler)
+	addTool(i, getDocLinks, p.getDocLinksHandler)
+	addTool(i, suggestQueries, p.suggestQueriesHandler)
+	addTool(i, indexStatus, p.indexStatusHandler)
+	addTool(i, searchTdsDocs, p.searchTdsDocsHandler)
+	addTool(i, getProjectContext, p.getProjectContextHandler)
+	i.AddResource(projectContextResource, p.projectContextResourceHandler)
 
 	p.Server = i
 	return p
@@ -227,15 +228,27 @@ func mcpClientInfo(req mcp.Request) *mcp.Implementation {
 func toolCallInfo(params mcp.Params) (skillName string, query string) {
 	switch params := params.(type) {
 	case *mcp.CallToolParamsRaw:
-		return params.Name, queryFromArguments(params.Arguments)
+		return params.Name, stringArgument(params.Arguments, "query")
 	case *mcp.CallToolParams:
-		return params.Name, queryFromArguments(params.Arguments)
+		return params.Name, stringArgument(params.Arguments, "query")
 	default:
 		return "", ""
 	}
 }
 
-func queryFromArguments(arguments any) string {
+// toolCallArgument는 tools/call 인자 중 name의 문자열 값을 반환합니다 (없거나 문자열이 아니면 빈 문자열)
+func toolCallArgument(params mcp.Params, name string) string {
+	switch params := params.(type) {
+	case *mcp.CallToolParamsRaw:
+		return stringArgument(params.Arguments, name)
+	case *mcp.CallToolParams:
+		return stringArgument(params.Arguments, name)
+	default:
+		return ""
+	}
+}
+
+func stringArgument(arguments any, name string) string {
 	if arguments == nil {
 		return ""
 	}
@@ -261,8 +274,8 @@ func queryFromArguments(arguments any) string {
 		}
 	}
 
-	query, _ := payload["query"].(string)
-	return query
+	value, _ := payload[name].(string)
+	return value
 }
 
 func searchResultCount(result mcp.Result) (int, bool) {
@@ -0,0 +1,71 @@
+package mcp
+
+import (
+	"context"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/toss/apps-in-toss-ax/pkg/search"
+)
+
+// progressReporter는 도구 호출이 인덱스 준비를 기다리는 동안 진행 상황을 호출자에게 알립니다.
+// step은 알릴 때마다 커지는 값입니다.
+type progressReporter func(step int, progress search.Progress)
+
+type progressReporterKey struct{}
+
+func withProgressReporter(ctx context.Context, report progressReporter) context.Context {
+	return context.WithValue(ctx, progressReporterKey{}, report)
+}
+
+func progressReporterFrom(ctx context.Context) progressReporter {
+	report, _ := ctx.Value(progressReporterKey{}).(progressReporter)
+	return report
+}
+
+// progressMiddleware는 progress token을 보낸 도구 호출에 notifications/progress로 인덱스 준비 상황을 알리는
+// progressReporter를 붙입니다
+func (p *Protocol) progressMiddleware() mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			if method != "tools/call" {
+				return next(ctx, method, req)
+			}
+			params, ok := req.GetParams().(mcp.RequestParams)
+			session, isServer := req.GetSession().(*mcp.ServerSession)
+			if !ok || !isServer || session == nil {
+				return next(ctx, method, req)
+			}
+			token := params.GetProgressToken()
+			if token == nil {
+				return next(ctx, method, req)
+			}
+
+			ctx = withProgressReporter(ctx, func(step int, progress search.Progress) {
+				_ = session.NotifyProgress(ctx, &mcp.ProgressNotificationParams{
+					ProgressToken: token,
+					Message:       progress.Message,
+					Progress:      float64(step),
+				})
+			})
+			return next(ctx, method, req)
+		}
+	}
+}
+
+// indexLogger는 corpus의 인덱스 준비 과정을 연결된 모든 세션에 notifications/message로 보냅니다.
+// 인덱스는 세션과 무관하게 백그라운드에서 준비되므로 특정 요청이 아닌 서버 전체의 로그입니다.
+// 클라이언트가 logging/setLevel로 수준을 정하지 않았으면 SDK가 보내지 않습니다.
+func (p *Protocol) indexLogger(corpus string) func(level mcp.LoggingLevel, message string) {
+	return func(level mcp.LoggingLevel, message string) {
+		if p.Server == nil {
+			return
+		}
+		for session := range p.Server.Sessions() {
+			_ = session.Log(context.Background(), &mcp.LoggingMessageParams{
+				Level:  level,
+				Logger: name + "/" + corpus,
+				Data:   message,
+			})
+		}
+	}
+}
@@ -0,0 +1,354 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/toss/apps-in-toss-ax/pkg/search"
+)
+
+const (
+	// frameworkPackage는 React Native 미니앱, webFrameworkPackage는 WebView 미니앱의 프레임워크 패키지입니다
+	frameworkPackage    = "@apps-in-toss/framework"
+	webFrameworkPackage = "@apps-in-toss/web-framework"
+
+	graniteConfigFile = "granite.config.ts"
+
+	// projectContextURI는 프로젝트 정보를 제공하는 리소스의 URI입니다
+	projectContextURI = "ax://project/context"
+)
+
+// 감지한 프레임워크의 이름입니다
+const (
+	frameworkReactNative = "react-native"
+	frameworkWeb         = "web"
+)
+
+// frameworkCorpus는 프레임워크에 맞는 TDS 문서 모음입니다
+var frameworkCorpus = map[string]string{
+	frameworkReactNative: corpusTdsRn,
+	frameworkWeb:         corpusTdsWeb,
+}
+
+// errFrameworkUnknown은 프로젝트의 프레임워크를 감지하지 못해 TDS 문서 모음을 고를 수 없음을 나타냅니다
+var errFrameworkUnknown = errors.New("could not detect @apps-in-toss/framework or @apps-in-toss/web-framework in the project")
+
+// projectCache는 세션별로 감지한 프로젝트 정보입니다. 클라이언트가 roots 변경을 알리거나 세션이 닫히면 지웁니다.
+type projectCache struct {
+	mu       sync.Mutex
+	sessions map[*mcp.ServerSession]GetProjectContextOutput
+	// watching은 닫힐 때 항목을 지우도록 기다리고 있는 세션입니다
+	watching map[*mcp.ServerSession]bool
+}
+
+func (c *projectCache) get(session *mcp.ServerSession) (GetProjectContextOutput, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	output, ok := c.sessions[session]
+	return output, ok
+}
+
+func (c *projectCache) set(session *mcp.ServerSession, output GetProjectContextOutput) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.sessions == nil {
+		c.sessions = map[*mcp.ServerSession]GetProjectContextOutput{}
+		c.watching = map[*mcp.ServerSession]bool{}
+	}
+	c.sessions[session] = output
+	if !c.watching[session] {
+		c.watching[session] = true
+		go func() {
+			_ = session.Wait()
+			c.closed(session)
+		}()
+	}
+}
+
+func (c *projectCache) forget(session *mcp.ServerSession) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.sessions, session)
+}
+
+func (c *projectCache) closed(session *mcp.ServerSession) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.sessions, session)
+	delete(c.watching, session)
+}
+
+func (c *projectCache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.sessions)
+}
+
+// projectContext는 클라이언트의 roots(지원하지 않으면 서버의 작업 디렉터리)에서 미니앱 프로젝트를 찾습니다.
+// roots를 읽지 못해 작업 디렉터리로 대신한 결과는 일시적인 실패일 수 있으므로 캐시하지 않습니다.
+func (p *Protocol) projectContext(ctx context.Context, session *mcp.ServerSession) GetProjectContextOutput {
+	if session != nil {
+		if output, ok := p.projects.get(session); ok {
+			return output
+		}
+	}
+
+	dirs, source, rootsErr := projectDirs(ctx, session)
+	output := GetProjectContextOutput{Projects: []ProjectInfo{}, Source: source}
+	for _, dir := range dirs {
+		output.Projects = append(output.Projects, findProjects(dir)...)
+	}
+	output.TdsCorpus = commonCorpus(output.Projects)
+
+	if session != nil && rootsErr == nil {
+		p.projects.set(session, output)
+	}
+	return output
+}
+
+// projectDirs는 살펴볼 디렉터리와 그 출처(roots 또는 cwd)를 반환합니다.
+// 클라이언트가 roots를 지원하는데 읽지 못해 작업 디렉터리로 대신했으면 그 에러도 반환합니다.
+func projectDirs(ctx context.Context, session *mcp.ServerSession) ([]string, string, error) {
+	var rootsErr error
+	if session != nil {
+		if params := session.InitializeParams(); params != nil && params.Capabilities != nil && params.Capabilities.RootsV2 != nil {
+			result, err := session.ListRoots(ctx, nil)
+			if err == nil {
+				var dirs []string
+				for _, root := range result.Roots {
+					if dir, ok := fileURIPath(root.URI); ok {
+						dirs = append(dirs, dir)
+					}
+				}
+				return dirs, "roots", nil
+			}
+			rootsErr = err
+		}
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, "cwd", rootsErr
+	}
+	return []string{cwd}, "cwd", rootsErr
+}
+
+// fileURIPath는 file:// URI를 로컬 경로로 바꿉니다
+func fileURIPath(uri string) (string, bool) {
+	return fileURIPathFor(uri, runtime.GOOS == "windows")
+}
+
+// fileURIPathFor는 fileURIPath를 운영체제와 상관없이 시험할 수 있도록 Windows 여부를 받습니다.
+// Windows에서는 file:///C:/work를 C:\work로, file://server/share를 UNC 경로 \\server\share로 바꿉니다.
+func fileURIPathFor(uri string, windows bool) (string, bool) {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" {
+		return "", false
+	}
+	host := u.Host
+	if strings.EqualFold(host, "localhost") {
+		host = ""
+	}
+
+	if !windows {
+		// 다른 호스트의 경로는 이 컴퓨터에서 열 수 없습니다
+		if host != "" {
+			return "", false
+		}
+		return u.Path, true
+	}
+
+	path := u.Path
+	if len(path) >= 3 && path[0] == '/' && isDriveLetter(path[1]) && path[2] == ':' {
+		path = path[1:]
+	}
+	if host != "" {
+		path = "//" + host + path
+	}
+	return strings.ReplaceAll(path, "/", `\`), path != ""
+}
+
+func isDriveLetter(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
+
+// findProjects는 dir이 미니앱 프로젝트이면 그 정보를, 아니면 바로 아래 디렉터리(모노레포의 패키지)에서 찾은 프로젝트를 반환합니다.
+// 아무것도 찾지 못하면 프레임워크가 빈 dir 하나를 반환합니다.
+func findProjects(dir string) []ProjectInfo {
+	if project := detectProject(dir); project.Framework != "" {
+		return []ProjectInfo{project}
+	}
+
+	var projects []ProjectInfo
+	entries, _ := os.ReadDir(dir)
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.IsDir() || name == "node_modules" || strings.HasPrefix(name, ".") {
+			continue
+		}
+		if project := detectProject(filepath.Join(dir, name)); project.Framework != "" {
+			projects = append(projects, project)
+		}
+	}
+	if len(projects) == 0 {
+		return []ProjectInfo{{Root: dir}}
+	}
+	return projects
+}
+
+// packageJSON은 package.json에서 프레임워크를 찾는 데 필요한 필드입니다
+type packageJSON struct {
+	Version          string            `json:"version"`
+	Dependencies     map[string]string `json:"dependencies"`
+	DevDependencies  map[string]string `json:"devDependencies"`
+	PeerDependencies map[string]string `json:"peerDependencies"`
+}
+
+func (pkg packageJSON) dependency(name string) (string, bool) {
+	for _, deps := range []map[string]string{pkg.Dependencies, pkg.DevDependencies, pkg.PeerDependencies} {
+		if version, ok := deps[name]; ok {
+			return version, true
+		}
+	}
+	return "", false
+}
+
+func readPackageJSON(path string) (packageJSON, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return packageJSON{}, false
+	}
+	var pkg packageJSON
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return packageJSON{}, false
+	}
+	return pkg, true
+}
+
+// detectProject는 dir의 package.json 의존성에서 프레임워크와 버전을 찾고,
+// 의존성에 없으면 granite.config.ts가 가져오는 패키지로 판단합니다
+func detectProject(dir string) ProjectInfo {
+	project := ProjectInfo{Root: dir}
+
+	if pkg, ok := readPackageJSON(filepath.Join(dir, "package.json")); ok {
+		// 두 패키지가 모두 있으면 WebView 프레임워크를 우선합니다 (web-framework가 framework에 의존할 수 있음)
+		for _, name := range []string{webFrameworkPackage, frameworkPackage} {
+			if version, ok := pkg.dependency(name); ok {
+				project.Package, project.Version = name, version
+				break
+			}
+		}
+	}
+
+	if config, err := os.ReadFile(filepath.Join(dir, graniteConfigFile)); err == nil {
+		project.GraniteConfig = true
+		if project.Package == "" {
+			switch text := string(config); {
+			case strings.Contains(text, webFrameworkPackage):
+				project.Package = webFrameworkPackage
+			case strings.Contains(text, frameworkPackage):
+				project.Package = frameworkPackage
+			}
+		}
+	}
+
+	switch project.Package {
+	case frameworkPackage:
+		project.Framework = frameworkReactNative
+	case webFrameworkPackage:
+		project.Framework = frameworkWeb
+	default:
+		return project
+	}
+	project.TdsCorpus = frameworkCorpus[project.Framework]
+
+	if installed, ok := readPackageJSON(filepath.Join(dir, "node_modules", filepath.FromSlash(project.Package), "package.json")); ok {
+		project.InstalledVersion = installed.Version
+	}
+	return project
+}
+
+// commonCorpus는 감지한 프로젝트들이 모두 같은 TDS 문서 모음을 쓰면 그 이름을, 아니면 빈 문자열을 반환합니다
+func commonCorpus(projects []ProjectInfo) string {
+	corpus := ""
+	for _, project := range projects {
+		if project.TdsCorpus == "" {
+			continue
+		}
+		if corpus != "" && corpus != project.TdsCorpus {
+			return ""
+		}
+		corpus = project.TdsCorpus
+	}
+	return corpus
+}
+
+var getProjectContext = &mcp.Tool{
+	Name:        "get_project_context",
+	Title:       "Get Project Context",
+	Description: "Detect the mini-app framework of the user's project from the client's roots: @apps-in-toss/framework (React Native) or @apps-in-toss/web-framework (WebView), with the version declared in package.json, the installed version and whether granite.config.ts exists. Returns the matching TDS corpus (tds-rn or tds-web). Call this instead of reading package.json yourself before choosing a TDS tool.",
+	Annotations: &mcp.ToolAnnotations{
+		Title:          "Get Project Context",
+		ReadOnlyHint:   true,
+		IdempotentHint: true,
+	},
+}
+
+func (p *Protocol) getProjectContextHandler(ctx context.Context, r *mcp.CallToolRequest, input GetProjectContextInput) (result *mcp.CallToolResult, output GetProjectContextOutput, err error) {
+	output = p.projectContext(ctx, requestSession(r))
+	return projectContextResult(output), output, nil
+}
+
+var projectContextResource = &mcp.Resource{
+	URI:         projectContextURI,
+	Name:        "project-context",
+	Title:       "Project Context",
+	Description: "Mini-app framework, versions and matching TDS corpus detected from the client's roots",
+	MIMEType:    "application/json",
+}
+
+func (p *Protocol) projectContextResourceHandler(ctx context.Context, r *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	var session *mcp.ServerSession
+	if r != nil {
+		session = r.Session
+	}
+	data, err := json.MarshalIndent(p.projectContext(ctx, session), "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return &mcp.ReadResourceResult{
+		Contents: []*mcp.ResourceContents{{URI: projectContextURI, MIMEType: "application/json", Text: string(data)}},
+	}, nil
+}
+
+// rootsListChanged는 클라이언트의 roots가 바뀌면 그 세션의 프로젝트 정보를 다시 감지하도록 지웁니다
+func (p *Protocol) rootsListChanged(_ context.Context, r *mcp.RootsListChangedRequest) {
+	if r != nil && r.Session != nil {
+		p.projects.forget(r.Session)
+	}
+}
+
+func requestSession(r *mcp.CallToolRequest) *mcp.ServerSession {
+	if r == nil {
+		return nil
+	}
+	return r.Session
+}
+
+// tdsCorpusFor는 프로젝트의 프레임워크에 맞는 TDS 문서 모음을 고릅니다
+func (p *Protocol) tdsCorpusFor(ctx context.Context, session *mcp.ServerSession) (string, error) {
+	output := p.projectContext(ctx, session)
+	if output.TdsCorpus == "" {
+		return "", search.InvalidArgument(fmt.Errorf("%w; call %s or %s instead", errFrameworkUnknown, searchTdsRnDocs.Name, searchTdsWebDocs.Name))
+	}
+	return output.TdsCorpus, nil
+}
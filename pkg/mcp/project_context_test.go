@@ -0,0 +1,169 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFileURIPathFor(t *testing.T) {
+	tests := []struct {
+		uri     string
+		windows bool
+		want    string
+		ok      bool
+	}{
+		{"file:///home/me/app", false, "/home/me/app", true},
+		{"file://localhost/home/me/app", false, "/home/me/app", true},
+		{"file://server/share/app", false, "", false},
+		{"file:///C:/work/app", true, `C:\work\app`, true},
+		{"file:///c%3A/work/my%20app", true, `c:\work\my app`, true},
+		{"file://localhost/D:/app", true, `D:\app`, true},
+		{"file://server/share/app", true, `\\server\share\app`, true},
+		{"https://example.com/app", true, "", false},
+	}
+	for _, tt := range tests {
+		got, ok := fileURIPathFor(tt.uri, tt.windows)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("fileURIPathFor(%q, %v) = %q, %v; want %q, %v", tt.uri, tt.windows, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestDetectProject(t *testing.T) {
+	rn := t.TempDir()
+	writeFile(t, filepath.Join(rn, "package.json"), `{"dependencies": {"@apps-in-toss/framework": "^1.2.0"}}`)
+	writeFile(t, filepath.Join(rn, "node_modules", "@apps-in-toss", "framework", "package.json"), `{"version": "1.2.3"}`)
+	writeFile(t, filepath.Join(rn, graniteConfigFile), `import { defineConfig } from '@apps-in-toss/framework/config';`)
+
+	web := t.TempDir()
+	writeFile(t, filepath.Join(web, "package.json"), `{"name": "app"}`)
+	writeFile(t, filepath.Join(web, graniteConfigFile), `import { defineConfig } from '@apps-in-toss/web-framework/config';`)
+
+	tests := []struct {
+		dir  string
+		want ProjectInfo
+	}{
+		{rn, ProjectInfo{Root: rn, Framework: frameworkReactNative, Package: frameworkPackage, Version: "^1.2.0", InstalledVersion: "1.2.3", GraniteConfig: true, TdsCorpus: corpusTdsRn}},
+		{web, ProjectInfo{Root: web, Framework: frameworkWeb, Package: webFrameworkPackage, GraniteConfig: true, TdsCorpus: corpusTdsWeb}},
+		{t.TempDir(), ProjectInfo{}},
+	}
+	for _, tt := range tests {
+		got := detectProject(tt.dir)
+		if tt.want.Root == "" {
+			tt.want.Root = tt.dir
+		}
+		if got != tt.want {
+			t.Errorf("detectProject(%s) = %+v, want %+v", tt.dir, got, tt.want)
+		}
+	}
+}
+
+func TestFindProjects_Monorepo(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "package.json"), `{"private": true}`)
+	writeFile(t, filepath.Join(root, "apps", "package.json"), `{"devDependencies": {"@apps-in-toss/web-framework": "1.0.0"}}`)
+	writeFile(t, filepath.Join(root, "node_modules", "x", "package.json"), `{"dependencies": {"@apps-in-toss/framework": "1.0.0"}}`)
+
+	projects := findProjects(root)
+	if len(projects) != 1 || projects[0].Root != filepath.Join(root, "apps") || projects[0].TdsCorpus != corpusTdsWeb {
+		t.Errorf("Unexpected projects %+v", projects)
+	}
+	if got := commonCorpus(append(projects, ProjectInfo{TdsCorpus: corpusTdsRn})); got != "" {
+		t.Errorf("Expected no common corpus for mixed projects, got %q", got)
+	}
+}
+
+func TestSearchTdsDocs_RoutesByRoots(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "package.json"), `{"dependencies": {"@apps-in-toss/web-framework": "^1.0.0"}}`)
+
+	p := New()
+	p.OnInit = func(context.Context) {}
+	p.tdsWeb = newLazySearcher(fakeSearcher)
+
+	ctx := context.Background()
+	serverTransport, clientTransport := mcpsdk.NewInMemoryTransports()
+	serverSession, err := p.Server.Connect(ctx, serverTransport, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer serverSession.Close()
+
+	client := mcpsdk.NewClient(&mcpsdk.Implementation{Name: "test", Version: "0.0.0"}, nil)
+	client.AddRoots(&mcpsdk.Root{URI: "file://" + filepath.ToSlash(root), Name: "app"})
+	session, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer session.Close()
+
+	result, err := session.CallTool(ctx, &mcpsdk.CallToolParams{Name: searchTdsDocs.Name, Arguments: map[string]any{"query": "Button"}})
+	if err != nil || result.IsError {
+		t.Fatalf("CallTool failed: %v %+v", err, result)
+	}
+	if structured, _ := result.StructuredContent.(map[string]any); structured["corpus"] != corpusTdsWeb {
+		t.Errorf("Expected the search to be routed to %s, got %v", corpusTdsWeb, result.StructuredContent)
+	}
+
+	resource, err := session.ReadResource(ctx, &mcpsdk.ReadResourceParams{URI: projectContextURI})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var output GetProjectContextOutput
+	if err := json.Unmarshal([]byte(resource.Contents[0].Text), &output); err != nil {
+		t.Fatal(err)
+	}
+	if output.Source != "roots" || output.TdsCorpus != corpusTdsWeb || len(output.Projects) != 1 || output.Projects[0].Version != "^1.0.0" {
+		t.Errorf("Unexpected project context %+v", output)
+	}
+
+	tool, err := session.CallTool(ctx, &mcpsdk.CallToolParams{Name: getProjectContext.Name})
+	if err != nil || tool.IsError {
+		t.Fatalf("CallTool failed: %v %+v", err, tool)
+	}
+	if text := tool.Content[0].(*mcpsdk.TextContent).Text; !strings.Contains(text, "web (`@apps-in-toss/web-framework` ^1.0.0)") {
+		t.Errorf("Unexpected project context text %q", text)
+	}
+
+	// roots를 읽지 못해 작업 디렉터리로 대신한 결과는 캐시하지 않습니다
+	p.projects.forget(serverSession)
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	if output := p.projectContext(canceled, serverSession); output.Source != "cwd" {
+		t.Errorf("Expected the cwd fallback, got %+v", output)
+	}
+	if _, ok := p.projects.get(serverSession); ok {
+		t.Error("Expected the cwd fallback after a roots error not to be cached")
+	}
+	p.projectContext(ctx, serverSession)
+	if n := p.projects.len(); n != 1 {
+		t.Fatalf("Expected the roots result to be cached, got %d entries", n)
+	}
+
+	// 세션이 닫히면 캐시에서 지웁니다
+	session.Close()
+	deadline := time.Now().Add(5 * time.Second)
+	for p.projects.len() > 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if n := p.projects.len(); n != 0 {
+		t.Errorf("Expected closed sessions to be removed from the cache, %d left", n)
+	}
+}
@@ -0,0 +1,140 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/toss/apps-in-toss-ax/pkg/search"
+)
+
+// promptArgProblem은 prompt 인자 하나가 빠졌거나 허용되지 않는 값인 경우입니다
+type promptArgProblem struct {
+	arg     *mcp.PromptArgument
+	value   string
+	allowed []string
+}
+
+func (pr promptArgProblem) String() string {
+	if pr.value == "" {
+		return fmt.Sprintf("missing argument %s (allowed: %s)", pr.arg.Name, strings.Join(pr.allowed, ", "))
+	}
+	return fmt.Sprintf("invalid value %q for argument %s (allowed: %s)", pr.value, pr.arg.Name, strings.Join(pr.allowed, ", "))
+}
+
+// matchAllowed는 value와 대소문자와 앞뒤 공백을 무시하고 같은 허용 값을 찾습니다
+func matchAllowed(value string, allowed []string) (string, bool) {
+	value = strings.TrimSpace(value)
+	for _, v := range allowed {
+		if strings.EqualFold(v, value) {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// resolvePromptArgs는 prompt의 인자를 CompletionRegistry에 등록된 값으로 검증하고 허용 값의 표기로 맞춘 인자를 반환합니다.
+// 빠졌거나(필수 인자) 허용되지 않는 인자가 있으면 클라이언트가 elicitation을 지원할 때 사용자에게 묻고,
+// 지원하지 않거나 사용자가 거절하면 기본값으로 추측하지 않고 ErrInvalidArgument로 분류된 에러를 반환합니다.
+func (p *Protocol) resolvePromptArgs(ctx context.Context, req *mcp.GetPromptRequest, prompt *mcp.Prompt) (map[string]string, error) {
+	args := map[string]string{}
+	if req.Params != nil {
+		for k, v := range req.Params.Arguments {
+			args[k] = v
+		}
+	}
+
+	var problems []promptArgProblem
+	for _, arg := range prompt.Arguments {
+		allowed := p.completions.Values(PromptRef(prompt.Name), arg.Name)
+		if len(allowed) == 0 {
+			continue
+		}
+		value := args[arg.Name]
+		if strings.TrimSpace(value) == "" && !arg.Required {
+			continue
+		}
+		if matched, ok := matchAllowed(value, allowed); ok {
+			args[arg.Name] = matched
+			continue
+		}
+		problems = append(problems, promptArgProblem{arg: arg, value: value, allowed: allowed})
+	}
+	if len(problems) == 0 {
+		return args, nil
+	}
+
+	if req.Session == nil || !supportsElicitation(req.Session) {
+		return nil, promptArgsError(prompt, problems)
+	}
+	answers, err := elicitPromptArgs(ctx, req.Session, prompt, problems)
+	if err != nil {
+		return nil, err
+	}
+	for _, problem := range problems {
+		matched, ok := matchAllowed(answers[problem.arg.Name], problem.allowed)
+		if !ok {
+			return nil, promptArgsError(prompt, problems)
+		}
+		args[problem.arg.Name] = matched
+	}
+	return args, nil
+}
+
+func promptArgsError(prompt *mcp.Prompt, problems []promptArgProblem) error {
+	messages := make([]string, 0, len(problems))
+	for _, problem := range problems {
+		messages = append(messages, problem.String())
+	}
+	return search.InvalidArgument(fmt.Errorf("prompt %s: %s", prompt.Name, strings.Join(messages, "; ")))
+}
+
+func supportsElicitation(session *mcp.ServerSession) bool {
+	params := session.InitializeParams()
+	return params != nil && params.Capabilities != nil && params.Capabilities.Elicitation != nil
+}
+
+// elicitPromptArgs는 문제가 있는 인자들을 허용 값 중에서 고르는 폼 하나로 사용자에게 묻습니다
+func elicitPromptArgs(ctx context.Context, session *mcp.ServerSession, prompt *mcp.Prompt, problems []promptArgProblem) (map[string]string, error) {
+	properties := map[string]any{}
+	required := make([]string, 0, len(problems))
+	lines := make([]string, 0, len(problems))
+	for _, problem := range problems {
+		title := problem.arg.Title
+		if title == "" {
+			title = problem.arg.Name
+		}
+		properties[problem.arg.Name] = map[string]any{
+			"type":        "string",
+			"title":       title,
+			"description": problem.arg.Description,
+			"enum":        problem.allowed,
+		}
+		required = append(required, problem.arg.Name)
+		lines = append(lines, "- "+problem.String())
+	}
+
+	result, err := session.Elicit(ctx, &mcp.ElicitParams{
+		Message: fmt.Sprintf("Choose the arguments for the %s prompt:\n%s", prompt.Name, strings.Join(lines, "\n")),
+		RequestedSchema: map[string]any{
+			"type":       "object",
+			"properties": properties,
+			"required":   required,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if result.Action != "accept" {
+		return nil, search.InvalidArgument(fmt.Errorf("prompt %s: the user did not choose the arguments (%s)", prompt.Name, result.Action))
+	}
+
+	answers := map[string]string{}
+	for name, value := range result.Content {
+		if text, ok := value.(string); ok {
+			answers[name] = text
+		}
+	}
+	return answers, nil
+}
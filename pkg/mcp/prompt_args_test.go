@@ -0,0 +1,83 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/toss/apps-in-toss-ax/pkg/search"
+)
+
+func TestResolvePromptArgs_WithoutElicitation(t *testing.T) {
+	plan := builtinPrompt(t, "miniapp-action-plan")
+	p := &Protocol{completions: NewCompletionRegistry()}
+	p.completions.RegisterAll(plan.completions())
+
+	args, err := p.resolvePromptArgs(context.Background(), &mcpsdk.GetPromptRequest{Params: &mcpsdk.GetPromptParams{
+		Arguments: map[string]string{"platform": " React-Native ", "package_manager": "pnpm"},
+	}}, plan.prompt)
+	if err != nil {
+		t.Fatalf("Expected valid arguments, got %v", err)
+	}
+	if args["platform"] != "react-native" || args["package_manager"] != "pnpm" {
+		t.Errorf("Expected canonical values, got %v", args)
+	}
+
+	_, err = p.resolvePromptArgs(context.Background(), &mcpsdk.GetPromptRequest{Params: &mcpsdk.GetPromptParams{
+		Arguments: map[string]string{"platform": "wbe"},
+	}}, plan.prompt)
+	if !errors.Is(err, search.ErrInvalidArgument) {
+		t.Fatalf("Expected invalid argument error, got %v", err)
+	}
+	for _, want := range []string{`invalid value "wbe" for argument platform (allowed: web, react-native)`, "missing argument package_manager"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected %q in %q", want, err.Error())
+		}
+	}
+}
+
+func TestMiniappActionPlan_ElicitsMissingArgument(t *testing.T) {
+	p := New()
+	p.OnInit = func(context.Context) {}
+
+	ctx := context.Background()
+	serverTransport, clientTransport := mcpsdk.NewInMemoryTransports()
+	serverSession, err := p.Server.Connect(ctx, serverTransport, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer serverSession.Close()
+
+	var asked []string
+	client := mcpsdk.NewClient(&mcpsdk.Implementation{Name: "test", Version: "0.0.0"}, &mcpsdk.ClientOptions{
+		ElicitationHandler: func(_ context.Context, req *mcpsdk.ElicitRequest) (*mcpsdk.ElicitResult, error) {
+			schema := req.Params.RequestedSchema.(map[string]any)
+			for name := range schema["properties"].(map[string]any) {
+				asked = append(asked, name)
+			}
+			return &mcpsdk.ElicitResult{Action: "accept", Content: map[string]any{"platform": "react-native"}}, nil
+		},
+	})
+	session, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer session.Close()
+
+	result, err := session.GetPrompt(ctx, &mcpsdk.GetPromptParams{
+		Name:      "miniapp-action-plan",
+		Arguments: map[string]string{"package_manager": "yarn"},
+	})
+	if err != nil {
+		t.Fatalf("GetPrompt failed: %v", err)
+	}
+	if len(asked) != 1 || asked[0] != "platform" {
+		t.Errorf("Expected to ask only for platform, asked %v", asked)
+	}
+	text := result.Messages[0].Content.(*mcpsdk.TextContent).Text
+	if !strings.Contains(text, "@apps-in-toss/framework") || !strings.Contains(text, "yarn dlx ait init") {
+		t.Errorf("Expected the React Native plan with yarn, got:\n%s", text)
+	}
+}
@@ -0,0 +1,270 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"gopkg.in/yaml.v3"
+)
+
+// prompts/*.md는 AX에 기본으로 들어 있는 prompt입니다
+//
+//go:embed prompts/*.md
+var builtinPromptFiles embed.FS
+
+// PromptTemplate은 YAML frontmatter가 붙은 Markdown 파일 하나로 정의된 prompt입니다.
+//
+// frontmatter는 name, title, description, result_description과 arguments를 선언하고,
+// 본문의 {{인자 이름}}은 인자 값으로, {{변수 이름}}은 고른 값의 variables로 바뀝니다.
+type PromptTemplate struct {
+	prompt            *mcp.Prompt
+	resultDescription string
+	body              string
+	// values는 인자 이름 -> 허용 값 목록입니다 (values를 선언한 인자만)
+	values map[string][]promptValue
+}
+
+// Name은 prompt 이름을 반환합니다
+func (t *PromptTemplate) Name() string {
+	return t.prompt.Name
+}
+
+type promptFrontmatter struct {
+	Name              string              `yaml:"name"`
+	Title             string              `yaml:"title"`
+	Description       string              `yaml:"description"`
+	ResultDescription string              `yaml:"result_description"`
+	Arguments         []promptArgumentDef `yaml:"arguments"`
+}
+
+type promptArgumentDef struct {
+	Name        string        `yaml:"name"`
+	Title       string        `yaml:"title"`
+	Description string        `yaml:"description"`
+	Required    bool          `yaml:"required"`
+	Values      []promptValue `yaml:"values"`
+}
+
+// promptValue는 인자의 허용 값 하나와 그 값을 골랐을 때 본문에 채울 변수입니다.
+// frontmatter에서는 `- web`처럼 값만 쓰거나 `- {value: web, variables: {...}}`로 씁니다.
+type promptValue struct {
+	Value     string            `yaml:"value"`
+	Variables map[string]string `yaml:"variables"`
+}
+
+func (v *promptValue) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		v.Value = node.Value
+		return nil
+	}
+	type plain promptValue
+	return node.Decode((*plain)(v))
+}
+
+// parsePromptTemplate은 frontmatter와 본문을 나눠 PromptTemplate을 만듭니다
+func parsePromptTemplate(data []byte) (*PromptTemplate, error) {
+	data = bytes.ReplaceAll(data, []byte("\r\n"), []byte("\n"))
+	if !bytes.HasPrefix(data, []byte("---\n")) {
+		return nil, errors.New("missing YAML frontmatter")
+	}
+	rest := data[len("---\n"):]
+	end := bytes.Index(rest, []byte("\n---\n"))
+	if end < 0 {
+		return nil, errors.New("unterminated YAML frontmatter")
+	}
+
+	var fm promptFrontmatter
+	if err := yaml.Unmarshal(rest[:end], &fm); err != nil {
+		return nil, fmt.Errorf("frontmatter: %w", err)
+	}
+	if strings.TrimSpace(fm.Name) == "" {
+		return nil, errors.New("frontmatter: name is required")
+	}
+
+	t := &PromptTemplate{
+		prompt: &mcp.Prompt{
+			Name:        fm.Name,
+			Title:       fm.Title,
+			Description: fm.Description,
+		},
+		resultDescription: fm.ResultDescription,
+		body:              string(rest[end+len("\n---\n"):]),
+		values:            map[string][]promptValue{},
+	}
+
+	seen := map[string]bool{}
+	for _, arg := range fm.Arguments {
+		if strings.TrimSpace(arg.Name) == "" {
+			return nil, errors.New("frontmatter: argument name is required")
+		}
+		if seen[arg.Name] {
+			return nil, fmt.Errorf("frontmatter: duplicate argument %s", arg.Name)
+		}
+		seen[arg.Name] = true
+
+		seenValues := map[string]bool{}
+		for _, v := range arg.Values {
+			if strings.TrimSpace(v.Value) == "" {
+				return nil, fmt.Errorf("frontmatter: argument %s has an empty value", arg.Name)
+			}
+			if seenValues[strings.ToLower(v.Value)] {
+				return nil, fmt.Errorf("frontmatter: argument %s has duplicate value %q", arg.Name, v.Value)
+			}
+			seenValues[strings.ToLower(v.Value)] = true
+		}
+
+		t.prompt.Arguments = append(t.prompt.Arguments, &mcp.PromptArgument{
+			Name:        arg.Name,
+			Title:       arg.Title,
+			Description: arg.Description,
+			Required:    arg.Required,
+		})
+		if len(arg.Values) > 0 {
+			t.values[arg.Name] = arg.Values
+		}
+	}
+	return t, nil
+}
+
+// loadPrompts는 fsys의 dir 바로 아래 *.md 파일을 모두 읽습니다. 같은 이름의 prompt가 두 번 나오면 에러입니다.
+func loadPrompts(fsys fs.FS, dir string) ([]*PromptTemplate, error) {
+	files, err := fs.Glob(fsys, path.Join(dir, "*.md"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+
+	templates := make([]*PromptTemplate, 0, len(files))
+	names := map[string]string{}
+	for _, file := range files {
+		data, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+		t, err := parsePromptTemplate(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		if prev, ok := names[t.Name()]; ok {
+			return nil, fmt.Errorf("%s: prompt %s is already defined in %s", file, t.Name(), prev)
+		}
+		names[t.Name()] = file
+		templates = append(templates, t)
+	}
+	return templates, nil
+}
+
+// LoadPromptDir는 dir의 *.md 파일을 prompt로 읽습니다. 하위 디렉터리는 읽지 않습니다.
+func LoadPromptDir(dir string) ([]*PromptTemplate, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", dir)
+	}
+	templates, err := loadPrompts(os.DirFS(dir), ".")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", dir, err)
+	}
+	return templates, nil
+}
+
+// builtinPrompts는 바이너리에 포함된 prompt를 읽습니다. 포함된 파일이 잘못된 것은 빌드 실수이므로 panic합니다.
+func builtinPrompts() []*PromptTemplate {
+	templates, err := loadPrompts(builtinPromptFiles, "prompts")
+	if err != nil {
+		panic(fmt.Sprintf("mcp: invalid built-in prompt: %v", err))
+	}
+	return templates
+}
+
+// completions는 values를 선언한 인자들의 자동 완성 항목입니다
+func (t *PromptTemplate) completions() []Completion {
+	var completions []Completion
+	for _, arg := range t.prompt.Arguments {
+		values, ok := t.values[arg.Name]
+		if !ok {
+			continue
+		}
+		allowed := make([]string, 0, len(values))
+		for _, v := range values {
+			allowed = append(allowed, v.Value)
+		}
+		completions = append(completions, Completion{Ref: PromptRef(t.Name()), Arg: arg.Name, Values: allowed})
+	}
+	return completions
+}
+
+// render는 검증된 인자로 본문과 결과 설명의 {{...}} 자리를 채웁니다.
+// 인자 값이 먼저 들어가고, 고른 값의 variables가 같은 이름이면 덮어씁니다.
+func (t *PromptTemplate) render(args map[string]string) (description, text string) {
+	vars := map[string]string{}
+	for _, arg := range t.prompt.Arguments {
+		vars[arg.Name] = args[arg.Name]
+	}
+	for _, arg := range t.prompt.Arguments {
+		for _, v := range t.values[arg.Name] {
+			if v.Value != args[arg.Name] {
+				continue
+			}
+			for k, val := range v.Variables {
+				vars[k] = val
+			}
+		}
+	}
+
+	pairs := make([]string, 0, len(vars)*2)
+	for k, v := range vars {
+		pairs = append(pairs, "{{"+k+"}}", v)
+	}
+	r := strings.NewReplacer(pairs...)
+	return r.Replace(t.resultDescription), r.Replace(t.body)
+}
+
+// registerPrompts는 기본 prompt와 WithPrompts로 받은 prompt를 서버와 CompletionRegistry에 등록합니다.
+// 사용자 prompt가 기본 prompt와 이름이 같으면 사용자 prompt가 이깁니다.
+func (p *Protocol) registerPrompts(server *mcp.Server) {
+	byName := map[string]*PromptTemplate{}
+	var order []string
+	for _, t := range append(builtinPrompts(), p.prompts...) {
+		if _, ok := byName[t.Name()]; !ok {
+			order = append(order, t.Name())
+		}
+		byName[t.Name()] = t
+	}
+
+	for _, name := range order {
+		t := byName[name]
+		server.AddPrompt(t.prompt, p.promptHandler(t))
+		p.completions.RegisterAll(t.completions())
+	}
+}
+
+func (p *Protocol) promptHandler(t *PromptTemplate) mcp.PromptHandler {
+	return func(ctx context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		args, err := p.resolvePromptArgs(ctx, req, t.prompt)
+		if err != nil {
+			return nil, err
+		}
+		description, text := t.render(args)
+		return &mcp.GetPromptResult{
+			Description: description,
+			Messages: []*mcp.PromptMessage{
+				{
+					Role:    "user",
+					Content: &mcp.TextContent{Text: text},
+				},
+			},
+		}, nil
+	}
+}
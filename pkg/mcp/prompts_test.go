@@ -0,0 +1,193 @@
+package mcp
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func builtinPrompt(t *testing.T, name string) *PromptTemplate {
+	t.Helper()
+	for _, tmpl := range builtinPrompts() {
+		if tmpl.Name() == name {
+			return tmpl
+		}
+	}
+	t.Fatalf("built-in prompt %s not found", name)
+	return nil
+}
+
+func TestParsePromptTemplate(t *testing.T) {
+	tmpl, err := parsePromptTemplate([]byte(`---
+name: review
+description: Review a change
+result_description: "{{scope}} review"
+arguments:
+  - name: scope
+    required: true
+    values:
+      - small
+      - value: large
+        variables:
+          checklist: "- [ ] split the change"
+  - name: focus
+---
+Review this {{scope}} change.
+{{checklist}}
+Focus: {{focus}}
+`))
+	if err != nil {
+		t.Fatalf("parsePromptTemplate failed: %v", err)
+	}
+	if tmpl.Name() != "review" || len(tmpl.prompt.Arguments) != 2 || !tmpl.prompt.Arguments[0].Required {
+		t.Fatalf("Unexpected prompt: %+v", tmpl.prompt)
+	}
+
+	completions := tmpl.completions()
+	if len(completions) != 1 || completions[0].Arg != "scope" || strings.Join(completions[0].Values, ",") != "small,large" {
+		t.Errorf("Expected completions only for scope, got %+v", completions)
+	}
+
+	description, text := tmpl.render(map[string]string{"scope": "large", "focus": "tests"})
+	if description != "large review" {
+		t.Errorf("Unexpected description %q", description)
+	}
+	if text != "Review this large change.\n- [ ] split the change\nFocus: tests\n" {
+		t.Errorf("Unexpected text %q", text)
+	}
+}
+
+func TestParsePromptTemplate_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"no frontmatter":     "# Title\n",
+		"unterminated":       "---\nname: x\n",
+		"missing name":       "---\ndescription: x\n---\nbody\n",
+		"duplicate argument": "---\nname: x\narguments:\n  - name: a\n  - name: a\n---\nbody\n",
+		"empty value":        "---\nname: x\narguments:\n  - name: a\n    values: ['']\n---\nbody\n",
+		"duplicate value":    "---\nname: x\narguments:\n  - name: a\n    values: [web, Web]\n---\nbody\n",
+		"invalid yaml":       "---\nname: [x\n---\nbody\n",
+	}
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := parsePromptTemplate([]byte(content)); err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+}
+
+func TestBuiltinPrompts(t *testing.T) {
+	plan := builtinPrompt(t, "miniapp-action-plan")
+
+	_, text := plan.render(map[string]string{"platform": "web", "package_manager": "pnpm"})
+	for _, want := range []string{"@apps-in-toss/web-framework", "@toss/tds-mobile", "pnpm dlx ait init", "search_tds_web_docs"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected %q in the web plan", want)
+		}
+	}
+	if strings.Contains(text, "{{") {
+		t.Errorf("Expected every placeholder to be filled, got:\n%s", text)
+	}
+}
+
+func TestLoadPromptDir(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("a.md", "---\nname: team-a\n---\nA\n")
+	write("notes.txt", "ignored")
+
+	templates, err := LoadPromptDir(dir)
+	if err != nil {
+		t.Fatalf("LoadPromptDir failed: %v", err)
+	}
+	if len(templates) != 1 || templates[0].Name() != "team-a" {
+		t.Errorf("Expected only team-a, got %d templates", len(templates))
+	}
+
+	write("b.md", "---\nname: team-a\n---\nB\n")
+	if _, err := LoadPromptDir(dir); err == nil || !strings.Contains(err.Error(), "already defined") {
+		t.Errorf("Expected a duplicate name error, got %v", err)
+	}
+
+	if _, err := LoadPromptDir(filepath.Join(dir, "missing")); err == nil {
+		t.Error("Expected an error for a missing directory")
+	}
+}
+
+func TestWithPrompts_RegistersAndOverrides(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"release.md": "---\nname: team-release\ndescription: Release checklist\narguments:\n  - name: channel\n    required: true\n    values: [beta, stable]\n---\nRelease to {{channel}}.\n",
+		"plan.md":    "---\nname: miniapp-action-plan\ndescription: Our own plan\n---\nTeam plan\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	templates, err := LoadPromptDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := New(WithPrompts(templates...))
+	p.OnInit = func(context.Context) {}
+
+	ctx := context.Background()
+	serverTransport, clientTransport := mcpsdk.NewInMemoryTransports()
+	serverSession, err := p.Server.Connect(ctx, serverTransport, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer serverSession.Close()
+
+	client := mcpsdk.NewClient(&mcpsdk.Implementation{Name: "test", Version: "0.0.0"}, nil)
+	session, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer session.Close()
+
+	list, err := session.ListPrompts(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Prompts) != 2 {
+		t.Errorf("Expected 2 prompts, got %d", len(list.Prompts))
+	}
+
+	complete, err := session.Complete(ctx, &mcpsdk.CompleteParams{
+		Ref:      &mcpsdk.CompleteReference{Type: "ref/prompt", Name: "team-release"},
+		Argument: mcpsdk.CompleteParamsArgument{Name: "channel", Value: "s"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(complete.Completion.Values, ",") != "stable" {
+		t.Errorf("Expected stable, got %v", complete.Completion.Values)
+	}
+
+	result, err := session.GetPrompt(ctx, &mcpsdk.GetPromptParams{Name: "team-release", Arguments: map[string]string{"channel": "Beta"}})
+	if err != nil {
+		t.Fatalf("GetPrompt failed: %v", err)
+	}
+	if text := result.Messages[0].Content.(*mcpsdk.TextContent).Text; text != "Release to beta.\n" {
+		t.Errorf("Unexpected text %q", text)
+	}
+
+	result, err = session.GetPrompt(ctx, &mcpsdk.GetPromptParams{Name: "miniapp-action-plan"})
+	if err != nil {
+		t.Fatalf("GetPrompt failed: %v", err)
+	}
+	if text := result.Messages[0].Content.(*mcpsdk.TextContent).Text; text != "Team plan\n" {
+		t.Errorf("Expected the team prompt to replace the built-in one, got %q", text)
+	}
+}
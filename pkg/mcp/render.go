@@ -0,0 +1,306 @@
+package mcp
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/toss/apps-in-toss-ax/pkg/search"
+)
+
+// 검색 결과 목록에서 각 문서의 미리보기 길이(문자 수)입니다
+const snippetLength = 200
+
+// toolResult는 사람이 읽을 수 있는 markdown 본문과 출처 페이지 링크로 CallToolResult를 만듭니다.
+// structuredContent는 SDK가 핸들러의 출력 값으로 채우므로, structuredContent를 무시하는 클라이언트도
+// JSON 대신 이 markdown을 보여줍니다.
+func toolResult(markdown string, links []*mcp.ResourceLink) *mcp.CallToolResult {
+	content := []mcp.Content{&mcp.TextContent{Text: markdown}}
+	seen := map[string]bool{}
+	for _, link := range links {
+		if link == nil || seen[link.URI] {
+			continue
+		}
+		seen[link.URI] = true
+		content = append(content, link)
+	}
+	return &mcp.CallToolResult{Content: content}
+}
+
+// sourceLink는 문서의 원본 페이지로 가는 ResourceLink를 만듭니다. URL이 없으면 nil입니다.
+func sourceLink(id, title, url, description string) *mcp.ResourceLink {
+	if url == "" {
+		return nil
+	}
+	return &mcp.ResourceLink{
+		URI:         url,
+		Name:        id,
+		Title:       title,
+		Description: description,
+	}
+}
+
+func resultLink(doc search.SearchResult) *mcp.ResourceLink {
+	return sourceLink(doc.ID, doc.Title, doc.URL, doc.Description)
+}
+
+// titleLink는 URL이 있으면 제목을 markdown 링크로 만듭니다
+func titleLink(title, url string) string {
+	if title == "" {
+		title = url
+	}
+	if url == "" {
+		return title
+	}
+	return fmt.Sprintf("[%s](%s)", title, url)
+}
+
+// citation은 문서의 출처 URL과 ID를 한 줄로 씁니다
+func citation(id, url string) string {
+	if url == "" {
+		return fmt.Sprintf("id: `%s`", id)
+	}
+	return fmt.Sprintf("Source: %s · id: `%s`", url, id)
+}
+
+// snippet은 검색 결과 목록에 보여줄 한 줄 미리보기입니다. 설명이 없으면 본문 앞부분을 사용합니다.
+func snippet(doc search.SearchResult) string {
+	text := doc.Description
+	if strings.TrimSpace(text) == "" {
+		text = doc.Content
+	}
+	text = strings.Join(strings.Fields(text), " ")
+	if utf8.RuneCountInString(text) > snippetLength {
+		text = string([]rune(text)[:snippetLength]) + "…"
+	}
+	return text
+}
+
+// codeFence는 text 안의 백틱보다 긴 코드 펜스를 반환합니다
+func codeFence(text string) string {
+	longest, run := 0, 0
+	for _, r := range text {
+		if r == '`' {
+			run++
+			longest = max(longest, run)
+		} else {
+			run = 0
+		}
+	}
+	return strings.Repeat("`", max(3, longest+1))
+}
+
+func searchResult(output SearchOutput) *mcp.CallToolResult {
+	var b strings.Builder
+	links := make([]*mcp.ResourceLink, 0, len(output.Results))
+
+	if output.Total == 0 {
+		b.WriteString("No documents found.\n")
+	} else {
+		fmt.Fprintf(&b, "Found %d documents", output.Total)
+		if output.Corpus != "" {
+			fmt.Fprintf(&b, " in %s", output.Corpus)
+		}
+		if output.Profile != "" {
+			fmt.Fprintf(&b, " (profile: %s)", output.Profile)
+		}
+		b.WriteString(".\n")
+	}
+	if output.TranslatedQuery != "" {
+		fmt.Fprintf(&b, "Also searched the Korean keywords `%s` and merged the results.\n", output.TranslatedQuery)
+	}
+	if output.Relaxation != "" {
+		fmt.Fprintf(&b, "Nothing matched the original query; these are approximate results (%s).\n", output.Relaxation)
+	}
+	if len(output.Suggestions) > 0 {
+		fmt.Fprintf(&b, "Did you mean: %s\n", strings.Join(output.Suggestions, ", "))
+	}
+
+	for i, doc := range output.Results {
+		fmt.Fprintf(&b, "\n%d. **%s**", i+1, titleLink(doc.Title, doc.URL))
+		if doc.Category != "" {
+			fmt.Fprintf(&b, " · %s", doc.Category)
+		}
+		fmt.Fprintf(&b, "\n   id: `%s` · score %.4f\n", doc.ID, doc.Score)
+		if text := snippet(doc); text != "" {
+			fmt.Fprintf(&b, "   %s\n", text)
+		}
+		links = append(links, resultLink(doc))
+	}
+
+	if len(output.Facets) > 0 {
+		categories := make([]string, 0, len(output.Facets))
+		for _, facet := range output.Facets {
+			categories = append(categories, fmt.Sprintf("%s (%d)", facet.Category, facet.Count))
+		}
+		fmt.Fprintf(&b, "\nCategories: %s\n", strings.Join(categories, ", "))
+	}
+
+	return toolResult(b.String(), links)
+}
+
+// writeDocument는 문서를 출처 머리말과 함께 markdown으로 씁니다
+func writeDocument(b *strings.Builder, doc *search.SearchResult) {
+	fmt.Fprintf(b, "# %s\n\n", doc.Title)
+	fmt.Fprintf(b, "> %s", citation(doc.ID, doc.URL))
+	if doc.Category != "" {
+		fmt.Fprintf(b, " · %s", doc.Category)
+	}
+	b.WriteString("\n\n")
+	b.WriteString(strings.TrimRight(doc.Content, "\n"))
+	b.WriteString("\n")
+}
+
+func docResult(output GetDocOutput) *mcp.CallToolResult {
+	var b strings.Builder
+	writeDocument(&b, output.Document)
+	if output.HasMore {
+		fmt.Fprintf(&b, "\n> Showing characters %d-%d of %d (~%d of %d tokens). Continue with offset=%d.\n",
+			output.Offset, output.NextOffset, output.TotalChars, output.EstimatedTokens, output.TotalTokens, output.NextOffset)
+	}
+	return toolResult(b.String(), []*mcp.ResourceLink{resultLink(*output.Document)})
+}
+
+func docsResult(output GetDocsOutput) *mcp.CallToolResult {
+	var b strings.Builder
+	var links []*mcp.ResourceLink
+	for i, doc := range output.Documents {
+		if i > 0 {
+			b.WriteString("\n---\n\n")
+		}
+		if doc.Document == nil {
+			fmt.Fprintf(&b, "`%s`: %s\n", doc.ID, doc.Error)
+			continue
+		}
+		writeDocument(&b, doc.Document)
+		if doc.HasMore {
+			fmt.Fprintf(&b, "\n> Cut to fit max_tokens (~%d of %d tokens). Continue with get_doc offset=%d.\n",
+				doc.EstimatedTokens, doc.TotalTokens, doc.NextOffset)
+		}
+		links = append(links, resultLink(*doc.Document))
+	}
+	return toolResult(b.String(), links)
+}
+
+func docLinksResult(output DocLinksOutput) *mcp.CallToolResult {
+	var b strings.Builder
+	var links []*mcp.ResourceLink
+	write := func(heading string, docs []LinkedDoc) {
+		fmt.Fprintf(&b, "## %s (%d)\n\n", heading, len(docs))
+		for _, doc := range docs {
+			fmt.Fprintf(&b, "- %s", titleLink(doc.Title, doc.URL))
+			if doc.ID != "" {
+				fmt.Fprintf(&b, " · %s id: `%s`", doc.Corpus, doc.ID)
+			}
+			b.WriteString("\n")
+			links = append(links, sourceLink(doc.ID, doc.Title, doc.URL, ""))
+		}
+		b.WriteString("\n")
+	}
+	write("Outlinks", output.Outlinks)
+	write("Backlinks", output.Backlinks)
+	return toolResult(strings.TrimRight(b.String(), "\n")+"\n", links)
+}
+
+func suggestionsResult(output SuggestQueriesOutput) *mcp.CallToolResult {
+	var b strings.Builder
+	write := func(heading string, queries []string) {
+		if len(queries) == 0 {
+			fmt.Fprintf(&b, "%s: none\n", heading)
+			return
+		}
+		fmt.Fprintf(&b, "%s: %s\n", heading, strings.Join(queries, ", "))
+	}
+	write("Completions", output.Completions)
+	write("Corrections", output.Corrections)
+	return toolResult(b.String(), nil)
+}
+
+func findResult(output FindInDocOutput) *mcp.CallToolResult {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d matching lines in %s (`%s`, %d lines)", output.TotalMatches, titleLink(output.Title, output.URL), output.ID, output.TotalLines)
+	shown := 0
+	for _, hunk := range output.Matches {
+		shown += len(hunk.MatchLines)
+	}
+	if shown < output.TotalMatches {
+		fmt.Fprintf(&b, ", showing the first %d", shown)
+	}
+	b.WriteString(".\n")
+
+	for _, hunk := range output.Matches {
+		width := len(fmt.Sprint(hunk.EndLine))
+		var lines strings.Builder
+		for i, line := range strings.Split(hunk.Text, "\n") {
+			fmt.Fprintf(&lines, "%*d | %s\n", width, hunk.StartLine+i, line)
+		}
+		fence := codeFence(lines.String())
+		fmt.Fprintf(&b, "\nLines %d-%d:\n%s\n%s%s\n", hunk.StartLine, hunk.EndLine, fence, lines.String(), fence)
+	}
+	return toolResult(b.String(), []*mcp.ResourceLink{sourceLink(output.ID, output.Title, output.URL, "")})
+}
+
+func outlineResult(output GetDocOutlineOutput) *mcp.CallToolResult {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n> %s · %d chars, ~%d tokens\n\n",
+		output.Title, citation(output.ID, output.URL), output.TotalChars, output.TotalTokens)
+	if len(output.Sections) == 0 {
+		b.WriteString("No headings.\n")
+	}
+	for _, section := range output.Sections {
+		fmt.Fprintf(&b, "%s- %s (`%s`) · %d chars, ~%d tokens · line %d\n",
+			strings.Repeat("  ", section.Depth), section.Title, section.Anchor, section.Chars, section.Tokens, section.Line)
+	}
+	return toolResult(b.String(), []*mcp.ResourceLink{sourceLink(output.ID, output.Title, output.URL, "")})
+}
+
+func indexStatusResult(output IndexStatusOutput) *mcp.CallToolResult {
+	var b strings.Builder
+	for _, status := range output.Corpora {
+		fmt.Fprintf(&b, "- **%s**: %s", status.Corpus, status.State)
+		if status.ServingPreviousIndex {
+			b.WriteString(" (serving the previous index)")
+		}
+		if status.Documents > 0 || status.State == string(indexReady) {
+			fmt.Fprintf(&b, " · %d documents", status.Documents)
+		}
+		if status.LastRefresh != "" {
+			fmt.Fprintf(&b, " · last refresh %s", status.LastRefresh)
+		}
+		if status.Error != "" {
+			fmt.Fprintf(&b, "\n  Error: %s", status.Error)
+		}
+		b.WriteString("\n")
+	}
+	return toolResult(b.String(), nil)
+}
+
+func projectContextResult(output GetProjectContextOutput) *mcp.CallToolResult {
+	var b strings.Builder
+	for _, project := range output.Projects {
+		if project.Framework == "" {
+			fmt.Fprintf(&b, "- `%s`: no AppsInToss framework found\n", project.Root)
+			continue
+		}
+		fmt.Fprintf(&b, "- `%s`: %s (`%s`", project.Root, project.Framework, project.Package)
+		if project.Version != "" {
+			fmt.Fprintf(&b, " %s", project.Version)
+		}
+		if project.InstalledVersion != "" {
+			fmt.Fprintf(&b, ", installed %s", project.InstalledVersion)
+		}
+		b.WriteString(")")
+		if project.GraniteConfig {
+			fmt.Fprintf(&b, " · %s", graniteConfigFile)
+		}
+		fmt.Fprintf(&b, " · TDS corpus: %s\n", project.TdsCorpus)
+	}
+	if len(output.Projects) == 0 {
+		b.WriteString("No project directories were shared by the client.\n")
+	}
+	if output.TdsCorpus != "" {
+		fmt.Fprintf(&b, "\nUse %s (or corpus=%s) for TDS questions.\n", searchTdsDocs.Name, output.TdsCorpus)
+	}
+	return toolResult(b.String(), nil)
+}
@@ -0,0 +1,70 @@
+package mcp
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/toss/apps-in-toss-ax/pkg/search"
+)
+
+func TestSearchResult_MarkdownAndLinks(t *testing.T) {
+	output := SearchOutput{
+		Results: []search.SearchResult{
+			{ID: "button", Title: "Button", URL: "https://tossmini-docs.toss.im/tds-mobile/components/button", Category: "컴포넌트", Score: 1.5, Description: "버튼 컴포넌트"},
+			{ID: "no-url", Title: "URL 없는 문서", Content: "본문\n  미리보기"},
+		},
+		Total: 2,
+	}
+
+	result := searchResult(output)
+
+	text, ok := result.Content[0].(*mcp.TextContent)
+	if !ok {
+		t.Fatalf("Expected text content first, got %T", result.Content[0])
+	}
+	for _, want := range []string{
+		"1. **[Button](https://tossmini-docs.toss.im/tds-mobile/components/button)** · 컴포넌트",
+		"id: `button` · score 1.5000",
+		"버튼 컴포넌트",
+		"2. **URL 없는 문서**",
+		"본문 미리보기",
+	} {
+		if !strings.Contains(text.Text, want) {
+			t.Errorf("Expected %q in\n%s", want, text.Text)
+		}
+	}
+
+	if len(result.Content) != 2 {
+		t.Fatalf("Expected one resource link for the document with a URL, got %d content blocks", len(result.Content))
+	}
+	link, ok := result.Content[1].(*mcp.ResourceLink)
+	if !ok || link.URI != output.Results[0].URL || link.Name != "button" || link.Title != "Button" {
+		t.Errorf("Unexpected resource link %+v", result.Content[1])
+	}
+}
+
+func TestDocResult_CitationHeader(t *testing.T) {
+	doc := &search.SearchResult{ID: "payment", Title: "결제", URL: "https://developers-apps-in-toss.toss.im/payment", Content: "본문입니다.\n"}
+	result := docResult(GetDocOutput{Document: doc, HasMore: true, NextOffset: 6, TotalChars: 20})
+
+	text := result.Content[0].(*mcp.TextContent).Text
+	if !strings.HasPrefix(text, "# 결제\n\n> Source: https://developers-apps-in-toss.toss.im/payment · id: `payment`\n\n본문입니다.\n") {
+		t.Errorf("Unexpected document markdown:\n%s", text)
+	}
+	if !strings.Contains(text, "Continue with offset=6") {
+		t.Errorf("Expected a continuation hint, got:\n%s", text)
+	}
+	if link := result.Content[1].(*mcp.ResourceLink); link.URI != doc.URL {
+		t.Errorf("Expected a link to the source page, got %+v", link)
+	}
+}
+
+func TestCodeFence_LongerThanContent(t *testing.T) {
+	if fence := codeFence("1 | ```ts\n2 | ```"); fence != "````" {
+		t.Errorf("Expected a four-backtick fence, got %q", fence)
+	}
+	if fence := codeFence("plain"); fence != "```" {
+		t.Errorf("Expected the default fence, got %q", fence)
+	}
+}
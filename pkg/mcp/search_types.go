@@ -1,6 +1,11 @@
 package mcp
 
-import "github.com/toss/apps-in-toss-ax/pkg/search"
+import (
+	"context"
+	"errors"
+
+	"github.com/toss/apps-in-toss-ax/pkg/search"
+)
 
 // SearchInput은 모든 검색 도구의 공통 입력 타입입니다
 type SearchInput struct {
@@ -13,6 +18,16 @@ type SearchInput struct {
 	DescriptionBoost *float64 `json:"description_boost,omitempty" jsonschema:"Relevance boost for description matches (default 1.5, valid range 0 to 1000000; at least one of the four boosts must stay > 0)."`
 	ContentBoost     *float64 `json:"content_boost,omitempty" jsonschema:"Relevance boost for body content matches (default 1.0, valid range 0 to 1000000; at least one of the four boosts must stay > 0). Raise it when searching for error messages or code identifiers that appear in document bodies rather than titles."`
 	CategoryBoost    *float64 `json:"category_boost,omitempty" jsonschema:"Relevance boost for category matches (default 1.0, valid range 0 to 1000000; at least one of the four boosts must stay > 0)."`
+	LinkBoost        *float64 `json:"link_boost,omitempty" jsonschema:"Ranking multiplier for pages that many other pages link to (default 0.1, valid range 0 to 1000000; 0 disables). The score is multiplied by 1 + link_boost * ln(1 + backlinks)."`
+
+	// 쿼리 의도별 부스트 프로필. 위의 *_boost 값이 프로필보다 우선합니다.
+	Profile string `json:"profile,omitempty" jsonschema:"Named boost profile for the query intent: component (component names like Button), error_message (error strings and codes), api_reference (API and function names like appLogin), guide (how-to and concept questions), or auto to pick one from the query. Explicit *_boost values override the profile."`
+
+	// 검색 문법 사용 여부. 켜면 구문, 제외어, 필드 지정, OR를 해석합니다.
+	Syntax bool `json:"syntax,omitempty" jsonschema:"Interpret the query with search syntax: \"exact phrase\", -excluded, +required, field:term (title, description, content, category, url) and OR between terms. Plain terms are combined with AND. Malformed queries are rejected with the error position."`
+
+	// 점수 설명 여부. 켜면 결과마다 필드별 점수 내역을 포함합니다.
+	Explain bool `json:"explain,omitempty" jsonschema:"Include a per-field score breakdown (title, description, content, category, backlinks) in each result, with fuzzy (typo-tolerant) term matches marked. Use it to understand why a page ranks where it does before adjusting boosts."`
 }
 
 // searchOptions는 SearchInput을 search.SearchOptions로 변환합니다
@@ -28,22 +43,289 @@ func (in SearchInput) searchOptions() *search.SearchOptions {
 			Description: in.DescriptionBoost,
 			Content:     in.ContentBoost,
 			Category:    in.CategoryBoost,
+			Links:       in.LinkBoost,
 		},
+		Syntax:  in.Syntax,
+		Profile: in.Profile,
+		Explain: in.Explain,
 	}
 }
 
+// searchOutput은 검색 결과와 실제로 적용된 프로필로 SearchOutput을 만듭니다
+func (in SearchInput) searchOutput(results []search.SearchResult) SearchOutput {
+	profile, _ := search.ResolveProfile(in.Profile, in.Query)
+	output := SearchOutput{
+		Results: results,
+		Total:   len(results),
+		Profile: profile,
+	}
+	if len(results) > 0 {
+		output.Relaxation = results[0].Relaxation
+	}
+	return output
+}
+
+// search는 searcher로 검색해 카테고리별 개수와 함께 반환합니다.
+// 결과가 없거나 완화한 검색으로 찾은 경우 교정한 쿼리도 함께 반환합니다.
+func (in SearchInput) search(ctx context.Context, searcher *search.Searcher) (SearchOutput, error) {
+	results, facets, err := searcher.SearchWithFacets(ctx, in.Query, in.searchOptions())
+	if err != nil {
+		return SearchOutput{}, err
+	}
+
+	output := in.searchOutput(results)
+	output.Facets = facets
+	if output.Total == 0 || output.Relaxation != "" {
+		// 교정 제안은 부가 정보이므로 실패해도 검색 결과는 그대로 반환합니다
+		output.Suggestions, _ = searcher.Suggest(ctx, in.Query, search.DefaultSuggestionLimit)
+	}
+	return output, nil
+}
+
 // SearchOutput은 모든 검색 도구의 공통 출력 타입입니다
 type SearchOutput struct {
-	Results []search.SearchResult `json:"results"`
-	Total   int                   `json:"total"`
+	Results []search.SearchResult `json:"results" jsonschema:"Matching documents ranked by relevance"`
+	Total   int                   `json:"total" jsonschema:"Number of results returned"`
+	// Profile은 적용된 부스트 프로필입니다 (auto로 고른 경우 포함). 번역한 쿼리가 다른 프로필을 고르면 ", "로 함께 씁니다.
+	Profile string `json:"profile,omitempty" jsonschema:"Boost profile that was applied, including one picked by auto; comma-separated when the translated query used a different one"`
+	// Relaxation은 원래 쿼리로 결과가 없어 완화한 검색을 사용했을 때 그 방식입니다
+	Relaxation string `json:"relaxation,omitempty" jsonschema:"How the query was relaxed when the original query matched nothing; comma-separated when merged results used different relaxations"`
+	// Suggestions는 결과가 없거나 근사 결과일 때 맞춤법을 교정한 쿼리입니다 ("did you mean")
+	Suggestions []string `json:"suggestions,omitempty" jsonschema:"Spelling-corrected queries when nothing or only approximate results matched"`
+	// Facets는 상위 limit개가 아닌 매칭된 모든 문서의 카테고리별 개수입니다 (최상위 → 두 번째 단계)
+	Facets []search.CategoryFacet `json:"facets,omitempty" jsonschema:"Number of matching documents per top-level and second-level category, over all matches rather than the returned page"`
+	// TranslatedQuery는 한국어가 아닌 쿼리를 클라이언트 모델(sampling)로 번역한 한국어 검색어입니다.
+	// 원래 쿼리와 이 쿼리의 결과를 합쳐 반환합니다.
+	TranslatedQuery string `json:"translated_query,omitempty" jsonschema:"Korean keywords the query was translated to with the client's model; results of both queries are merged"`
+	// Corpus는 search_tds_docs가 프로젝트에 맞춰 고른 문서 모음입니다
+	Corpus string `json:"corpus,omitempty" jsonschema:"Corpus chosen from the project's framework (search_tds_docs only); pass it to get_doc and related tools"`
+}
+
+// SuggestQueriesInput은 검색어 자동 완성 도구의 입력 타입입니다
+type SuggestQueriesInput struct {
+	Query  string `json:"query" jsonschema:"Partial or misspelled search query"`
+	Corpus string `json:"corpus,omitempty" jsonschema:"Corpus to take terms from: docs (AppsInToss, default), tds-rn (TDS React Native) or tds-web (TDS Web)"`
+	Limit  int    `json:"limit,omitempty" jsonschema:"Maximum number of suggestions (default 5)"`
+}
+
+// SuggestQueriesOutput은 검색어 자동 완성 도구의 출력 타입입니다
+type SuggestQueriesOutput struct {
+	// Completions는 마지막 단어를 인덱스에 있는 단어로 완성한 쿼리입니다
+	Completions []string `json:"completions" jsonschema:"The query with its last word completed, most frequent first"`
+	// Corrections는 맞춤법을 교정한 쿼리입니다
+	Corrections []string `json:"corrections" jsonschema:"The query with misspelled words corrected"`
 }
 
 // GetDocInput은 문서 조회 도구의 입력 타입입니다
 type GetDocInput struct {
 	ID string `json:"id"`
+
+	// 긴 문서를 나눠 읽기 위한 크기 제한과 시작 위치. 생략하면 문서 전체를 반환합니다.
+	MaxTokens int    `json:"max_tokens,omitempty" jsonschema:"Maximum estimated tokens of content to return. The content is cut at a heading or paragraph boundary and never inside a code block. Omit to return the whole document."`
+	MaxChars  int    `json:"max_chars,omitempty" jsonschema:"Maximum characters of content to return, cut at the same boundaries as max_tokens."`
+	Offset    int    `json:"offset,omitempty" jsonschema:"Character offset to start from. Pass next_offset from the previous call to continue reading."`
+	Section   string `json:"section,omitempty" jsonschema:"Heading text or anchor to start from (e.g. 에러 코드 or 에러-코드). Overrides offset."`
+
+	// 줄 범위. find_in_doc이 반환한 줄 번호로 필요한 부분만 읽습니다.
+	StartLine int `json:"start_line,omitempty" jsonschema:"First line to return (1-based), e.g. a start_line from find_in_doc. Cannot be combined with offset, section or size limits."`
+	EndLine   int `json:"end_line,omitempty" jsonschema:"Last line to return (inclusive). Omit to read to the end of the document."`
+}
+
+// pageOptions는 GetDocInput을 search.PageOptions로 변환합니다
+func (in GetDocInput) pageOptions() search.PageOptions {
+	return search.PageOptions{
+		Offset:    in.Offset,
+		Section:   in.Section,
+		MaxChars:  in.MaxChars,
+		MaxTokens: in.MaxTokens,
+	}
+}
+
+// page는 입력에 따라 content에서 줄 범위나 페이지를 잘라 반환합니다
+func (in GetDocInput) page(content string) (search.Page, error) {
+	if in.StartLine <= 0 && in.EndLine <= 0 {
+		return search.PaginateContent(content, in.pageOptions())
+	}
+	if in.Offset > 0 || in.Section != "" || in.MaxTokens > 0 || in.MaxChars > 0 {
+		return search.Page{}, search.InvalidArgument(errors.New("start_line and end_line cannot be combined with offset, section, max_tokens or max_chars"))
+	}
+	page, _, err := search.LineRange(content, in.StartLine, in.EndLine)
+	return page, err
 }
 
 // GetDocOutput은 문서 조회 도구의 출력 타입입니다
 type GetDocOutput struct {
-	Document *search.SearchResult `json:"document,omitempty"`
+	Document *search.SearchResult `json:"document,omitempty" jsonschema:"The document; content holds the requested part"`
+
+	// Document.Content가 본문의 어느 부분인지와 남은 분량입니다
+	Offset          int  `json:"offset" jsonschema:"Character offset of the returned content in the document"`
+	NextOffset      int  `json:"next_offset,omitempty" jsonschema:"Offset to pass to continue reading, present when has_more is true"`
+	HasMore         bool `json:"has_more" jsonschema:"Whether content remains after the returned part"`
+	EstimatedTokens int  `json:"estimated_tokens" jsonschema:"Estimated tokens of the returned content"`
+	TotalChars      int  `json:"total_chars" jsonschema:"Characters in the whole document"`
+	TotalTokens     int  `json:"total_tokens" jsonschema:"Estimated tokens of the whole document"`
+}
+
+// newGetDocOutput은 doc의 본문을 page로 바꾼 GetDocOutput을 만듭니다
+func newGetDocOutput(doc *search.SearchResult, page search.Page) GetDocOutput {
+	paged := *doc
+	paged.Content = page.Content
+
+	output := GetDocOutput{
+		Document:        &paged,
+		Offset:          page.Offset,
+		HasMore:         page.HasMore,
+		EstimatedTokens: page.EstimatedTokens,
+		TotalChars:      page.TotalChars,
+		TotalTokens:     page.TotalTokens,
+	}
+	if page.HasMore {
+		output.NextOffset = page.End
+	}
+	return output
+}
+
+// RelatedDocsInput은 관련 문서 조회 도구의 입력 타입입니다
+type RelatedDocsInput struct {
+	ID     string `json:"id" jsonschema:"Document ID from search or get_doc results"`
+	Corpus string `json:"corpus,omitempty" jsonschema:"Corpus the document belongs to: docs (AppsInToss, default), tds-rn (TDS React Native) or tds-web (TDS Web)"`
+	Limit  int    `json:"limit,omitempty" jsonschema:"Maximum number of related documents to return (default 5)"`
+}
+
+// DocLinksInput은 문서 링크 조회 도구의 입력 타입입니다
+type DocLinksInput struct {
+	ID     string `json:"id" jsonschema:"Document ID from search or get_doc results"`
+	Corpus string `json:"corpus,omitempty" jsonschema:"Corpus the document belongs to: docs (AppsInToss, default), tds-rn (TDS React Native) or tds-web (TDS Web)"`
+}
+
+// LinkedDoc은 링크로 연결된 문서입니다. 인덱스에서 찾지 못한 링크는 URL만 채워집니다.
+type LinkedDoc struct {
+	ID     string `json:"id,omitempty" jsonschema:"Document ID, absent when the page is not indexed"`
+	Corpus string `json:"corpus,omitempty" jsonschema:"Corpus of the document: docs, tds-rn or tds-web"`
+	Title  string `json:"title,omitempty" jsonschema:"Document title"`
+	URL    string `json:"url" jsonschema:"Page URL"`
+}
+
+// DocLinksOutput은 문서 링크 조회 도구의 출력 타입입니다
+type DocLinksOutput struct {
+	Outlinks  []LinkedDoc `json:"outlinks" jsonschema:"Pages the document links to"`
+	Backlinks []LinkedDoc `json:"backlinks" jsonschema:"Pages that link to the document"`
+}
+
+// GetDocsInput은 여러 문서를 한 번에 조회하는 도구의 입력 타입입니다
+type GetDocsInput struct {
+	IDs       []string `json:"ids" jsonschema:"Document IDs from search results (at most 20), returned in the same order"`
+	Corpus    string   `json:"corpus,omitempty" jsonschema:"Corpus to look the IDs up in: docs (AppsInToss), tds-rn (TDS React Native) or tds-web (TDS Web). Omit to look each ID up in all corpora."`
+	MaxTokens int      `json:"max_tokens,omitempty" jsonschema:"Maximum estimated tokens of content across all documents. Earlier IDs are filled first; documents that do not fit are cut or left empty with has_more. Omit to return every document in full."`
+}
+
+// BatchDoc은 여러 문서 조회 결과 중 ID 하나의 결과입니다. 찾지 못한 ID는 Error만 채워집니다.
+type BatchDoc struct {
+	ID       string               `json:"id" jsonschema:"Requested document ID"`
+	Corpus   string               `json:"corpus,omitempty" jsonschema:"Corpus the document was found in: docs, tds-rn or tds-web"`
+	Document *search.SearchResult `json:"document,omitempty" jsonschema:"The document, absent when it was not found"`
+	Error    string               `json:"error,omitempty" jsonschema:"Why the document could not be returned"`
+	Code     search.ErrorCode     `json:"code,omitempty" jsonschema:"Error code when the document could not be returned: not_found, index_unavailable, network or internal"`
+
+	// Document.Content가 본문의 어느 부분까지인지와 남은 분량입니다. 이어 읽으려면 get_doc에 next_offset을 넘깁니다.
+	HasMore         bool `json:"has_more" jsonschema:"Whether the content was cut to fit max_tokens"`
+	NextOffset      int  `json:"next_offset,omitempty" jsonschema:"Offset to pass to the matching get tool to continue reading"`
+	EstimatedTokens int  `json:"estimated_tokens" jsonschema:"Estimated tokens of the returned content"`
+	TotalTokens     int  `json:"total_tokens" jsonschema:"Estimated tokens of the whole document"`
+}
+
+// GetDocsOutput은 여러 문서 조회 도구의 출력 타입입니다
+type GetDocsOutput struct {
+	Documents []BatchDoc `json:"documents" jsonschema:"One entry per requested ID, in request order"`
+	// EstimatedTokens는 반환한 본문 전체의 추정 토큰 수입니다
+	EstimatedTokens int `json:"estimated_tokens" jsonschema:"Estimated tokens of all returned content"`
+}
+
+// FindInDocInput은 문서 내 검색 도구의 입력 타입입니다
+type FindInDocInput struct {
+	ID         string `json:"id" jsonschema:"Document ID from search results"`
+	Corpus     string `json:"corpus,omitempty" jsonschema:"Corpus the document belongs to: docs (AppsInToss, default), tds-rn (TDS React Native) or tds-web (TDS Web)"`
+	Pattern    string `json:"pattern" jsonschema:"Keyword to find (case-insensitive), or a regular expression when regex is true"`
+	Regex      bool   `json:"regex,omitempty" jsonschema:"Interpret pattern as a regular expression (RE2 syntax)"`
+	Context    *int   `json:"context,omitempty" jsonschema:"Lines of context before and after each match (default 2)"`
+	MaxMatches int    `json:"max_matches,omitempty" jsonschema:"Maximum number of matching lines (default 20)"`
+}
+
+// findOptions는 FindInDocInput을 search.FindOptions로 변환합니다
+func (in FindInDocInput) findOptions() search.FindOptions {
+	opts := search.FindOptions{Regex: in.Regex, Context: -1, MaxMatches: in.MaxMatches}
+	if in.Context != nil {
+		opts.Context = max(*in.Context, 0)
+	}
+	return opts
+}
+
+// FindInDocOutput은 문서 내 검색 도구의 출력 타입입니다
+type FindInDocOutput struct {
+	ID    string `json:"id" jsonschema:"Document ID"`
+	Title string `json:"title" jsonschema:"Document title"`
+	URL   string `json:"url" jsonschema:"Source page URL for citation"`
+	// Matches는 일치한 줄과 앞뒤 문맥입니다. start_line과 end_line을 get_doc에 넘기면 해당 부분을 읽습니다.
+	Matches      []search.FindHunk `json:"matches" jsonschema:"Matching lines with context; overlapping context is merged into one span"`
+	TotalMatches int               `json:"total_matches" jsonschema:"Number of matching lines before max_matches was applied"`
+	TotalLines   int               `json:"total_lines" jsonschema:"Number of lines in the document"`
+}
+
+// GetDocOutlineInput은 문서 목차 조회 도구의 입력 타입입니다
+type GetDocOutlineInput struct {
+	ID     string `json:"id" jsonschema:"Document ID from search results"`
+	Corpus string `json:"corpus,omitempty" jsonschema:"Corpus the document belongs to: docs (AppsInToss, default), tds-rn (TDS React Native) or tds-web (TDS Web)"`
+}
+
+// GetDocOutlineOutput은 문서 목차 조회 도구의 출력 타입입니다
+type GetDocOutlineOutput struct {
+	ID    string `json:"id" jsonschema:"Document ID"`
+	Title string `json:"title" jsonschema:"Document title"`
+	URL   string `json:"url" jsonschema:"Source page URL for citation"`
+	// Sections는 문서 순서의 제목 목록입니다. parent와 depth로 트리를 나타냅니다.
+	Sections    []search.OutlineSection `json:"sections" jsonschema:"Headings in document order; parent and depth describe the tree"`
+	TotalChars  int                     `json:"total_chars" jsonschema:"Characters in the whole document"`
+	TotalTokens int                     `json:"total_tokens" jsonschema:"Estimated tokens of the whole document"`
+}
+
+// IndexStatusInput은 인덱스 상태 조회 도구의 입력 타입입니다
+type IndexStatusInput struct{}
+
+// CorpusIndexStatus는 문서 모음 하나의 검색 인덱스 준비 상태입니다
+type CorpusIndexStatus struct {
+	Corpus string `json:"corpus" jsonschema:"Corpus name: docs, tds-rn or tds-web"`
+	State  string `json:"state" jsonschema:"idle (not started), warming (downloading or indexing), ready or failed"`
+	// ServingPreviousIndex는 준비하는 동안 디스크에 남은 이전 인덱스로 응답하고 있는지입니다
+	ServingPreviousIndex bool   `json:"serving_previous_index,omitempty" jsonschema:"True while warming if searches are answered from the previous index"`
+	Documents            int    `json:"documents" jsonschema:"Number of indexed documents"`
+	LastRefresh          string `json:"last_refresh,omitempty" jsonschema:"When the source was last checked or downloaded (RFC3339)"`
+	Error                string `json:"error,omitempty" jsonschema:"Why the last warm-up or refresh failed"`
+}
+
+// IndexStatusOutput은 인덱스 상태 조회 도구의 출력 타입입니다
+type IndexStatusOutput struct {
+	Corpora []CorpusIndexStatus `json:"corpora" jsonschema:"Index status of each corpus"`
+}
+
+// GetProjectContextInput은 프로젝트 정보 조회 도구의 입력 타입입니다
+type GetProjectContextInput struct{}
+
+// ProjectInfo는 미니앱 프로젝트 하나에서 감지한 프레임워크 정보입니다
+type ProjectInfo struct {
+	Root string `json:"root" jsonschema:"Project directory"`
+	// Framework는 react-native 또는 web이며, 감지하지 못하면 비어 있습니다
+	Framework        string `json:"framework,omitempty" jsonschema:"react-native (@apps-in-toss/framework) or web (@apps-in-toss/web-framework); empty if not detected"`
+	Package          string `json:"package,omitempty" jsonschema:"Framework package name"`
+	Version          string `json:"version,omitempty" jsonschema:"Version range declared in package.json"`
+	InstalledVersion string `json:"installed_version,omitempty" jsonschema:"Version installed in node_modules"`
+	GraniteConfig    bool   `json:"granite_config" jsonschema:"Whether granite.config.ts exists"`
+	TdsCorpus        string `json:"tds_corpus,omitempty" jsonschema:"TDS corpus for this project: tds-rn or tds-web"`
+}
+
+// GetProjectContextOutput은 프로젝트 정보 조회 도구와 리소스의 출력 타입입니다
+type GetProjectContextOutput struct {
+	Projects []ProjectInfo `json:"projects" jsonschema:"Projects found in the client's roots"`
+	// TdsCorpus는 감지한 프로젝트가 모두 같은 TDS 문서 모음을 쓸 때의 그 이름입니다
+	TdsCorpus string `json:"tds_corpus,omitempty" jsonschema:"TDS corpus used by every detected project; empty if none or mixed"`
+	Source    string `json:"source" jsonschema:"Where the directories came from: roots (the client's roots) or cwd (the server's working directory)"`
 }
@@ -1,6 +1,10 @@
 package mcp
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/toss/apps-in-toss-ax/pkg/search"
+)
 
 func floatPtr(v float64) *float64 {
 	return &v
@@ -26,6 +30,7 @@ func TestSearchInputSearchOptions_BoostPassthrough(t *testing.T) {
 		DescriptionBoost: floatPtr(0.5),
 		ContentBoost:     floatPtr(3.0),
 		CategoryBoost:    floatPtr(0),
+		LinkBoost:        floatPtr(0.5),
 	}
 
 	opts := input.searchOptions()
@@ -45,4 +50,85 @@ func TestSearchInputSearchOptions_BoostPassthrough(t *testing.T) {
 	if opts.Boosts.Category == nil || *opts.Boosts.Category != 0 {
 		t.Errorf("Expected category boost 0, got %v", opts.Boosts.Category)
 	}
+	if opts.Boosts.Links == nil || *opts.Boosts.Links != 0.5 {
+		t.Errorf("Expected link boost 0.5, got %v", opts.Boosts.Links)
+	}
+}
+
+func TestSearchInputSearchOptions_SyntaxPassthrough(t *testing.T) {
+	opts := SearchInput{Query: `"appLogin" -unity`, Syntax: true}.searchOptions()
+
+	if !opts.Syntax {
+		t.Error("Expected syntax option to be passed through")
+	}
+}
+
+func TestSearchInputSearchOptions_ExplainPassthrough(t *testing.T) {
+	opts := SearchInput{Query: "결제", Explain: true}.searchOptions()
+
+	if !opts.Explain {
+		t.Error("Expected explain option to be passed through")
+	}
+}
+
+func TestSearchInputSearchOutput_ReportsProfile(t *testing.T) {
+	input := SearchInput{Query: "appLogin", Profile: "auto"}
+
+	if opts := input.searchOptions(); opts.Profile != "auto" {
+		t.Errorf("Expected profile auto to be passed through, got %q", opts.Profile)
+	}
+
+	output := input.searchOutput(nil)
+	if output.Profile != "api_reference" {
+		t.Errorf("Expected auto profile to resolve to api_reference, got %q", output.Profile)
+	}
+	if output.Total != 0 {
+		t.Errorf("Expected total 0, got %d", output.Total)
+	}
+}
+
+func TestNewGetDocOutput_Paged(t *testing.T) {
+	doc := &search.SearchResult{ID: "payment", Title: "결제", Content: "# 결제\n\n본문입니다.\n\n## 에러 코드\n\n코드 표\n"}
+
+	page, err := search.PaginateContent(doc.Content, GetDocInput{ID: "payment", MaxChars: 15}.pageOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	output := newGetDocOutput(doc, page)
+
+	if !output.HasMore || output.NextOffset != page.End {
+		t.Errorf("Expected next_offset %d with has_more, got %+v", page.End, output)
+	}
+	if output.Document.Content != page.Content {
+		t.Errorf("Expected paged content %q, got %q", page.Content, output.Document.Content)
+	}
+	if doc.Content == page.Content {
+		t.Error("Expected the original document to be left untouched")
+	}
+}
+
+func TestGetDocInputPage_LineRange(t *testing.T) {
+	content := "# 결제\n\n본문입니다.\n\n## 에러 코드\n\n코드 표\n"
+
+	page, err := GetDocInput{ID: "payment", StartLine: 5, EndLine: 7}.page(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page.Content != "## 에러 코드\n\n코드 표" || page.HasMore {
+		t.Errorf("Expected lines 5-7, got %+v", page)
+	}
+
+	if _, err := (GetDocInput{ID: "payment", StartLine: 5, MaxTokens: 10}).page(content); err == nil {
+		t.Error("Expected an error when combining a line range with a size limit")
+	}
+}
+
+func TestFindInDocInputFindOptions_Context(t *testing.T) {
+	if opts := (FindInDocInput{}).findOptions(); opts.Context >= 0 {
+		t.Errorf("Expected the default context when omitted, got %d", opts.Context)
+	}
+	zero := 0
+	if opts := (FindInDocInput{Context: &zero}).findOptions(); opts.Context != 0 {
+		t.Errorf("Expected no context lines, got %d", opts.Context)
+	}
 }
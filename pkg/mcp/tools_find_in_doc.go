@@ -0,0 +1,56 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/toss/apps-in-toss-ax/pkg/search"
+)
+
+var findInDoc = &mcp.Tool{
+	Name:        "find_in_doc",
+	Title:       "Find in Document",
+	Description: "Find lines in one document that contain a keyword or match a regular expression, with surrounding context and line numbers. Use this instead of reading a long page in full when you need one table or paragraph (e.g. an error code); then pass start_line/end_line to get_doc to read exactly that span.",
+	Annotations: &mcp.ToolAnnotations{
+		Title:          "Find in Document",
+		ReadOnlyHint:   true,
+		IdempotentHint: true,
+	},
+}
+
+func (p *Protocol) findInDocHandler(ctx context.Context, r *mcp.CallToolRequest, input FindInDocInput) (result *mcp.CallToolResult, output FindInDocOutput, err error) {
+	ls, err := p.searcherFor(input.Corpus)
+	if err != nil {
+		return nil, FindInDocOutput{}, err
+	}
+
+	searcher, release, err := ls.get(ctx)
+	if err != nil {
+		return nil, FindInDocOutput{}, err
+	}
+	defer release()
+
+	doc, err := searcher.GetDocument(ctx, input.ID)
+	if err != nil {
+		return nil, FindInDocOutput{}, err
+	}
+	if doc == nil {
+		return nil, FindInDocOutput{}, fmt.Errorf("%w: %s", search.ErrDocumentNotFound, input.ID)
+	}
+
+	found, err := search.FindInContent(doc.Content, input.Pattern, input.findOptions())
+	if err != nil {
+		return nil, FindInDocOutput{}, err
+	}
+
+	output = FindInDocOutput{
+		ID:           doc.ID,
+		Title:        doc.Title,
+		URL:          doc.URL,
+		Matches:      nonNil(found.Hunks),
+		TotalMatches: found.TotalMatches,
+		TotalLines:   found.TotalLines,
+	}
+	return findResult(output), output, nil
+}
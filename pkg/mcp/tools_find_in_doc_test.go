@@ -0,0 +1,17 @@
+package mcp
+
+import (
+	"context"
+	"testing"
+)
+
+func TestFindInDocHandler_NotFound(t *testing.T) {
+	p := &Protocol{docSearcher: newLazySearcher(fakeSearcher)}
+
+	if _, _, err := p.findInDocHandler(context.Background(), nil, FindInDocInput{ID: "missing", Pattern: "에러"}); err == nil {
+		t.Error("Expected an error for a missing document")
+	}
+	if _, _, err := p.findInDocHandler(context.Background(), nil, FindInDocInput{ID: "missing", Corpus: "unity", Pattern: "에러"}); err == nil {
+		t.Error("Expected an error for an unknown corpus")
+	}
+}
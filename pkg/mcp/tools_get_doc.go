@@ -5,12 +5,13 @@ import (
 	"fmt"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/toss/apps-in-toss-ax/pkg/search"
 )
 
 var getDoc = &mcp.Tool{
 	Name:        "get_doc",
 	Title:       "Get AppsInToss Document",
-	Description: "Retrieve the full content of an AppsInToss document by its ID. Use this after search_docs to get the complete document content.",
+	Description: "Retrieve the full content of an AppsInToss document by its ID. Use this after search_docs to get the complete document content. For long pages pass max_tokens (and then next_offset as offset while has_more is true) or a section heading to read it in parts, or start_line/end_line from find_in_doc to read exactly those lines.",
 	Annotations: &mcp.ToolAnnotations{
 		Title:          "Get AppsInToss Document",
 		ReadOnlyHint:   true,
@@ -21,7 +22,7 @@ var getDoc = &mcp.Tool{
 var getTdsRnDoc = &mcp.Tool{
 	Name:        "get_tds_rn_doc",
 	Title:       "Get TDS React Native Document",
-	Description: "Retrieve the full content of a TDS React Native document by its ID. Use this after search_tds_rn_docs to get the complete document content.",
+	Description: "Retrieve the full content of a TDS React Native document by its ID. Use this after search_tds_rn_docs to get the complete document content. For long pages pass max_tokens (and then next_offset as offset while has_more is true) or a section heading to read it in parts, or start_line/end_line from find_in_doc to read exactly those lines.",
 	Annotations: &mcp.ToolAnnotations{
 		Title:          "Get TDS React Native Document",
 		ReadOnlyHint:   true,
@@ -32,7 +33,7 @@ var getTdsRnDoc = &mcp.Tool{
 var getTdsWebDoc = &mcp.Tool{
 	Name:        "get_tds_web_doc",
 	Title:       "Get TDS Web Document",
-	Description: "Retrieve the full content of a TDS Web document by its ID. Use this after search_tds_web_docs to get the complete document content.",
+	Description: "Retrieve the full content of a TDS Web document by its ID. Use this after search_tds_web_docs to get the complete document content. For long pages pass max_tokens (and then next_offset as offset while has_more is true) or a section heading to read it in parts, or start_line/end_line from find_in_doc to read exactly those lines.",
 	Annotations: &mcp.ToolAnnotations{
 		Title:          "Get TDS Web Document",
 		ReadOnlyHint:   true,
@@ -41,30 +42,37 @@ var getTdsWebDoc = &mcp.Tool{
 }
 
 func (p *Protocol) getDocHandler(ctx context.Context, r *mcp.CallToolRequest, input GetDocInput) (result *mcp.CallToolResult, output GetDocOutput, err error) {
-	return p.getDocFromSearcher(ctx, p.docSearcher, input.ID)
+	return p.getDocFromSearcher(ctx, p.docSearcher, input)
 }
 
 func (p *Protocol) getTdsRnDocHandler(ctx context.Context, r *mcp.CallToolRequest, input GetDocInput) (result *mcp.CallToolResult, output GetDocOutput, err error) {
-	return p.getDocFromSearcher(ctx, p.tdsRn, input.ID)
+	return p.getDocFromSearcher(ctx, p.tdsRn, input)
 }
 
 func (p *Protocol) getTdsWebDocHandler(ctx context.Context, r *mcp.CallToolRequest, input GetDocInput) (result *mcp.CallToolResult, output GetDocOutput, err error) {
-	return p.getDocFromSearcher(ctx, p.tdsWeb, input.ID)
+	return p.getDocFromSearcher(ctx, p.tdsWeb, input)
 }
 
-func (p *Protocol) getDocFromSearcher(ctx context.Context, ls *lazySearcher, id string) (*mcp.CallToolResult, GetDocOutput, error) {
-	searcher, err := ls.get(ctx)
+func (p *Protocol) getDocFromSearcher(ctx context.Context, ls *lazySearcher, input GetDocInput) (*mcp.CallToolResult, GetDocOutput, error) {
+	searcher, release, err := ls.get(ctx)
 	if err != nil {
 		return nil, GetDocOutput{}, err
 	}
+	defer release()
 
-	doc, err := searcher.GetDocument(ctx, id)
+	doc, err := searcher.GetDocument(ctx, input.ID)
 	if err != nil {
 		return nil, GetDocOutput{}, err
 	}
 	if doc == nil {
-		return nil, GetDocOutput{}, fmt.Errorf("document not found: %s", id)
+		return nil, GetDocOutput{}, fmt.Errorf("%w: %s", search.ErrDocumentNotFound, input.ID)
 	}
 
-	return nil, GetDocOutput{Document: doc}, nil
+	page, err := input.page(doc.Content)
+	if err != nil {
+		return nil, GetDocOutput{}, err
+	}
+
+	output := newGetDocOutput(doc, page)
+	return docResult(output), output, nil
 }
@@ -0,0 +1,92 @@
+package mcp
+
+import (
+	"context"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+var getDocLinks = &mcp.Tool{
+	Name:        "get_doc_links",
+	Title:       "Get Document Links",
+	Description: "List the pages a document links to (outlinks) and the pages that link to it (backlinks), resolved to document IDs across AppsInToss, TDS React Native and TDS Web docs. Use this to follow references from a guide or to find which guides use a component.",
+	Annotations: &mcp.ToolAnnotations{
+		Title:          "Get Document Links",
+		ReadOnlyHint:   true,
+		IdempotentHint: true,
+	},
+}
+
+func (p *Protocol) getDocLinksHandler(ctx context.Context, r *mcp.CallToolRequest, input DocLinksInput) (result *mcp.CallToolResult, output DocLinksOutput, err error) {
+	source := input.Corpus
+	if source == "" {
+		source = corpusDocs
+	}
+	ls, err := p.searcherFor(source)
+	if err != nil {
+		return nil, DocLinksOutput{}, err
+	}
+
+	searcher, release, err := ls.get(ctx)
+	if err != nil {
+		return nil, DocLinksOutput{}, err
+	}
+	defer release()
+
+	links, err := searcher.DocumentLinks(ctx, input.ID)
+	if err != nil {
+		return nil, DocLinksOutput{}, err
+	}
+
+	// 원본 문서의 코퍼스부터 확인하고, 나머지 코퍼스에서 다른 코퍼스로 가는 링크와 역링크를 찾습니다.
+	// 다른 코퍼스의 인덱스를 준비하지 못하면 해당 코퍼스의 링크는 URL만 남깁니다.
+	order := []string{source}
+	for _, corpus := range corpora {
+		if corpus != source {
+			order = append(order, corpus)
+		}
+	}
+
+	resolved := map[string]LinkedDoc{}
+	output = DocLinksOutput{Outlinks: []LinkedDoc{}, Backlinks: []LinkedDoc{}}
+	for _, corpus := range order {
+		ls, _ := p.searcherFor(corpus)
+		s, release, err := ls.get(ctx)
+		if err != nil {
+			continue
+		}
+		defer release()
+
+		var pending []string
+		for _, key := range links.Outlinks {
+			if _, ok := resolved[key]; !ok {
+				pending = append(pending, key)
+			}
+		}
+		docs, err := s.ResolveLinks(ctx, pending)
+		if err != nil {
+			return nil, DocLinksOutput{}, err
+		}
+		for key, doc := range docs {
+			resolved[key] = LinkedDoc{ID: doc.ID, Corpus: corpus, Title: doc.Title, URL: doc.URL}
+		}
+
+		backlinks, err := s.Backlinks(ctx, links.LinkKey)
+		if err != nil {
+			return nil, DocLinksOutput{}, err
+		}
+		for _, doc := range backlinks {
+			output.Backlinks = append(output.Backlinks, LinkedDoc{ID: doc.ID, Corpus: corpus, Title: doc.Title, URL: doc.URL})
+		}
+	}
+
+	for _, key := range links.Outlinks {
+		if doc, ok := resolved[key]; ok {
+			output.Outlinks = append(output.Outlinks, doc)
+		} else {
+			output.Outlinks = append(output.Outlinks, LinkedDoc{URL: key})
+		}
+	}
+
+	return docLinksResult(output), output, nil
+}
@@ -0,0 +1,52 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/toss/apps-in-toss-ax/pkg/search"
+)
+
+var getDocOutline = &mcp.Tool{
+	Name:        "get_doc_outline",
+	Title:       "Get Document Outline",
+	Description: "Get the heading structure of a document with the size of each section (characters and estimated tokens) and its anchor, without the content. Use this to see how a long page is organized before reading it, then pass a section anchor to get_doc.",
+	Annotations: &mcp.ToolAnnotations{
+		Title:          "Get Document Outline",
+		ReadOnlyHint:   true,
+		IdempotentHint: true,
+	},
+}
+
+func (p *Protocol) getDocOutlineHandler(ctx context.Context, r *mcp.CallToolRequest, input GetDocOutlineInput) (result *mcp.CallToolResult, output GetDocOutlineOutput, err error) {
+	ls, err := p.searcherFor(input.Corpus)
+	if err != nil {
+		return nil, GetDocOutlineOutput{}, err
+	}
+
+	searcher, release, err := ls.get(ctx)
+	if err != nil {
+		return nil, GetDocOutlineOutput{}, err
+	}
+	defer release()
+
+	doc, err := searcher.GetDocument(ctx, input.ID)
+	if err != nil {
+		return nil, GetDocOutlineOutput{}, err
+	}
+	if doc == nil {
+		return nil, GetDocOutlineOutput{}, fmt.Errorf("%w: %s", search.ErrDocumentNotFound, input.ID)
+	}
+
+	output = GetDocOutlineOutput{
+		ID:          doc.ID,
+		Title:       doc.Title,
+		URL:         doc.URL,
+		Sections:    nonNil(search.Outline(doc.Content)),
+		TotalChars:  utf8.RuneCountInString(doc.Content),
+		TotalTokens: search.EstimateTokens(doc.Content),
+	}
+	return outlineResult(output), output, nil
+}
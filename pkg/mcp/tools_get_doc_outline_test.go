@@ -0,0 +1,17 @@
+package mcp
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGetDocOutlineHandler_NotFound(t *testing.T) {
+	p := &Protocol{docSearcher: newLazySearcher(fakeSearcher)}
+
+	if _, _, err := p.getDocOutlineHandler(context.Background(), nil, GetDocOutlineInput{ID: "missing"}); err == nil {
+		t.Error("Expected an error for a missing document")
+	}
+	if _, _, err := p.getDocOutlineHandler(context.Background(), nil, GetDocOutlineInput{ID: "missing", Corpus: "unity"}); err == nil {
+		t.Error("Expected an error for an unknown corpus")
+	}
+}
@@ -0,0 +1,133 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/toss/apps-in-toss-ax/pkg/search"
+)
+
+var getDocs = &mcp.Tool{
+	Name:        "get_docs",
+	Title:       "Get Multiple Documents",
+	Description: "Retrieve several documents by ID in one call, across AppsInToss, TDS React Native and TDS Web docs. Use this instead of repeated get_doc calls after a search. Pass max_tokens to cap the total size; documents that do not fit come back cut or empty with has_more, and IDs that are not found are reported per document without failing the call.",
+	Annotations: &mcp.ToolAnnotations{
+		Title:          "Get Multiple Documents",
+		ReadOnlyHint:   true,
+		IdempotentHint: true,
+	},
+}
+
+const (
+	// maxGetDocsIDs는 get_docs 한 번에 조회할 수 있는 최대 문서 수입니다
+	maxGetDocsIDs = 20
+	// getDocsConcurrency는 동시에 찾는 문서 수입니다. ID마다 여러 코퍼스를 확인할 수 있으므로 제한합니다.
+	getDocsConcurrency = 4
+)
+
+func (p *Protocol) getDocsHandler(ctx context.Context, r *mcp.CallToolRequest, input GetDocsInput) (result *mcp.CallToolResult, output GetDocsOutput, err error) {
+	if len(input.IDs) == 0 {
+		return nil, GetDocsOutput{}, search.InvalidArgument(errors.New("ids must contain at least one document ID"))
+	}
+	if len(input.IDs) > maxGetDocsIDs {
+		return nil, GetDocsOutput{}, search.InvalidArgument(fmt.Errorf("ids must contain at most %d document IDs, got %d", maxGetDocsIDs, len(input.IDs)))
+	}
+
+	// 코퍼스를 지정하면 그 코퍼스에서만, 아니면 모든 코퍼스에서 차례로 찾습니다
+	order := corpora
+	if input.Corpus != "" {
+		if _, err := p.searcherFor(input.Corpus); err != nil {
+			return nil, GetDocsOutput{}, err
+		}
+		order = []string{input.Corpus}
+	}
+
+	docs := make([]BatchDoc, len(input.IDs))
+	found := make([]*search.SearchResult, len(input.IDs))
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, getDocsConcurrency)
+	for i, id := range input.IDs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			docs[i] = BatchDoc{ID: id}
+			corpus, doc, err := p.findDocument(ctx, order, id)
+			switch {
+			case err != nil:
+				docs[i].Error, docs[i].Code = err.Error(), search.CodeOf(err)
+			case doc == nil:
+				docs[i].Error, docs[i].Code = fmt.Sprintf("%s: %s", search.ErrDocumentNotFound, id), search.ErrorNotFound
+			default:
+				docs[i].Corpus = corpus
+				found[i] = doc
+			}
+		}()
+	}
+	wg.Wait()
+
+	// 찾은 문서만 요청 순서대로 예산을 나눕니다
+	var contents []string
+	for _, doc := range found {
+		if doc != nil {
+			contents = append(contents, doc.Content)
+		}
+	}
+	pages := search.ApplyTokenBudget(contents, input.MaxTokens)
+
+	output = GetDocsOutput{Documents: docs}
+	next := 0
+	for i, doc := range found {
+		if doc == nil {
+			continue
+		}
+		page := pages[next]
+		next++
+
+		paged := *doc
+		paged.Content = page.Content
+		docs[i].Document = &paged
+		docs[i].HasMore = page.HasMore
+		docs[i].EstimatedTokens = page.EstimatedTokens
+		docs[i].TotalTokens = page.TotalTokens
+		if page.HasMore {
+			docs[i].NextOffset = page.End
+		}
+		output.EstimatedTokens += page.EstimatedTokens
+	}
+
+	return docsResult(output), output, nil
+}
+
+// findDocument는 order의 코퍼스를 차례로 확인해 id 문서를 찾습니다.
+// 인덱스를 준비하지 못한 코퍼스는 건너뛰고 나머지에서 계속 찾습니다. 어느 코퍼스에서도 찾지 못했는데
+// 실패한 코퍼스가 하나라도 있으면, 그 코퍼스에 문서가 있을 수 있으므로 not found 대신 마지막 에러를 반환합니다.
+func (p *Protocol) findDocument(ctx context.Context, order []string, id string) (string, *search.SearchResult, error) {
+	var lastErr error
+	for _, corpus := range order {
+		ls, err := p.searcherFor(corpus)
+		if err != nil {
+			return "", nil, err
+		}
+		s, release, err := ls.get(ctx)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		defer release()
+
+		doc, err := s.GetDocument(ctx, id)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if doc != nil {
+			return corpus, doc, nil
+		}
+	}
+	return "", nil, lastErr
+}
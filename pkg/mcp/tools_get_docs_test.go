@@ -0,0 +1,80 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/toss/apps-in-toss-ax/pkg/search"
+)
+
+func TestGetDocsHandler_ReportsNotFoundPerID(t *testing.T) {
+	p := &Protocol{
+		docSearcher: newLazySearcher(fakeSearcher),
+		tdsRn:       newLazySearcher(fakeSearcher),
+		tdsWeb:      newLazySearcher(fakeSearcher),
+	}
+
+	_, output, err := p.getDocsHandler(context.Background(), nil, GetDocsInput{IDs: []string{"a", "b"}, MaxTokens: 100})
+	if err != nil {
+		t.Fatalf("get_docs failed: %v", err)
+	}
+	if len(output.Documents) != 2 {
+		t.Fatalf("Expected one entry per ID, got %+v", output.Documents)
+	}
+	for i, id := range []string{"a", "b"} {
+		doc := output.Documents[i]
+		if doc.ID != id || doc.Document != nil || doc.Error == "" {
+			t.Errorf("Expected a not-found entry for %s, got %+v", id, doc)
+		}
+	}
+}
+
+func TestGetDocsHandler_WarmingCorpusIsNotNotFound(t *testing.T) {
+	defer func(wait time.Duration) { warmupWait = wait }(warmupWait)
+	warmupWait = 10 * time.Millisecond
+
+	release := make(chan struct{})
+	defer close(release)
+	warming := newLazySearcher(func() (*search.Searcher, error) {
+		<-release
+		return fakeSearcher()
+	})
+	warming.warm(context.Background())
+
+	p := &Protocol{
+		docSearcher: newLazySearcher(fakeSearcher),
+		tdsRn:       warming,
+		tdsWeb:      newLazySearcher(fakeSearcher),
+	}
+
+	_, output, err := p.getDocsHandler(context.Background(), nil, GetDocsInput{IDs: []string{"a"}})
+	if err != nil {
+		t.Fatalf("get_docs failed: %v", err)
+	}
+	doc := output.Documents[0]
+	if doc.Document != nil || doc.Code != search.ErrorIndexUnavailable {
+		t.Errorf("Expected index_unavailable while tds-rn is warming, got %+v", doc)
+	}
+}
+
+func TestGetDocsHandler_InvalidInput(t *testing.T) {
+	p := &Protocol{docSearcher: newLazySearcher(fakeSearcher)}
+
+	if _, _, err := p.getDocsHandler(context.Background(), nil, GetDocsInput{}); err == nil {
+		t.Error("Expected an error without IDs")
+	}
+	if _, _, err := p.getDocsHandler(context.Background(), nil, GetDocsInput{IDs: []string{"a"}, Corpus: "unity"}); err == nil {
+		t.Error("Expected an error for an unknown corpus")
+	}
+
+	ids := make([]string, maxGetDocsIDs+1)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("doc-%d", i)
+	}
+	if _, _, err := p.getDocsHandler(context.Background(), nil, GetDocsInput{IDs: ids}); !errors.Is(err, search.ErrInvalidArgument) {
+		t.Errorf("Expected an invalid argument error for %d IDs, got %v", len(ids), err)
+	}
+}
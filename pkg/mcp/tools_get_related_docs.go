@@ -0,0 +1,42 @@
+package mcp
+
+import (
+	"context"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+var getRelatedDocs = &mcp.Tool{
+	Name:        "get_related_docs",
+	Title:       "Get Related Documents",
+	Description: "Find documents related to a given document (similar content or the same category) in the same corpus. Use this after get_doc to discover adjacent pages such as error codes, refunds or follow-up guides without guessing new search queries.",
+	Annotations: &mcp.ToolAnnotations{
+		Title:          "Get Related Documents",
+		ReadOnlyHint:   true,
+		IdempotentHint: true,
+	},
+}
+
+func (p *Protocol) getRelatedDocsHandler(ctx context.Context, r *mcp.CallToolRequest, input RelatedDocsInput) (result *mcp.CallToolResult, output SearchOutput, err error) {
+	ls, err := p.searcherFor(input.Corpus)
+	if err != nil {
+		return nil, SearchOutput{}, err
+	}
+
+	searcher, release, err := ls.get(ctx)
+	if err != nil {
+		return nil, SearchOutput{}, err
+	}
+	defer release()
+
+	results, err := searcher.RelatedDocuments(ctx, input.ID, input.Limit)
+	if err != nil {
+		return nil, SearchOutput{}, err
+	}
+
+	output = SearchOutput{
+		Results: results,
+		Total:   len(results),
+	}
+	return searchResult(output), output, nil
+}
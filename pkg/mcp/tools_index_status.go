@@ -0,0 +1,42 @@
+package mcp
+
+import (
+	"context"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+var indexStatus = &mcp.Tool{
+	Name:        "index_status",
+	Title:       "Search Index Status",
+	Description: "Report whether each documentation index (docs, tds-rn, tds-web) is warming, ready or failed, with its document count and when the source was last refreshed. Call this when a search fails with index_unavailable to see whether the index is still being built.",
+	Annotations: &mcp.ToolAnnotations{
+		Title:          "Search Index Status",
+		ReadOnlyHint:   true,
+		IdempotentHint: true,
+	},
+}
+
+func (p *Protocol) indexStatusHandler(ctx context.Context, r *mcp.CallToolRequest, input IndexStatusInput) (result *mcp.CallToolResult, output IndexStatusOutput, err error) {
+	output.Corpora = make([]CorpusIndexStatus, 0, len(corpora))
+	for _, corpus := range corpora {
+		ls, err := p.searcherFor(corpus)
+		if err != nil || ls == nil {
+			continue
+		}
+		status := ls.status()
+		status.Corpus = corpus
+		output.Corpora = append(output.Corpora, status)
+	}
+	return indexStatusResult(output), output, nil
+}
+
+// warmIndexes는 세 문서 모음의 인덱스 준비를 동시에 백그라운드에서 시작합니다.
+// 첫 검색 호출이 내려받기와 색인을 기다리지 않도록 클라이언트가 초기화를 마치면 호출합니다.
+func (p *Protocol) warmIndexes(ctx context.Context) {
+	for _, corpus := range corpora {
+		if ls, err := p.searcherFor(corpus); err == nil && ls != nil {
+			ls.warm(ctx)
+		}
+	}
+}
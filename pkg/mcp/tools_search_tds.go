@@ -0,0 +1,43 @@
+package mcp
+
+import (
+	"context"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+var searchTdsDocs = &mcp.Tool{
+	Name:        "search_tds_docs",
+	Title:       "Search TDS Documents",
+	Description: "Search the TDS (Toss Design System) documentation that matches the user's project: TDS React Native for @apps-in-toss/framework projects, TDS Web for @apps-in-toss/web-framework projects, detected from the client's roots. Takes the same parameters as search_tds_rn_docs. If the framework cannot be detected, call search_tds_rn_docs or search_tds_web_docs directly.",
+	Annotations: &mcp.ToolAnnotations{
+		Title:          "Search TDS Documents",
+		ReadOnlyHint:   true,
+		IdempotentHint: true,
+	},
+}
+
+func (p *Protocol) searchTdsDocsHandler(ctx context.Context, r *mcp.CallToolRequest, input SearchInput) (result *mcp.CallToolResult, output SearchOutput, err error) {
+	corpus, err := p.tdsCorpusFor(ctx, requestSession(r))
+	if err != nil {
+		return nil, SearchOutput{}, err
+	}
+	ls, err := p.searcherFor(corpus)
+	if err != nil {
+		return nil, SearchOutput{}, err
+	}
+
+	searcher, release, err := ls.get(ctx)
+	if err != nil {
+		return nil, SearchOutput{}, err
+	}
+	defer release()
+
+	output, err = searchTranslated(ctx, r, searcher, input)
+	if err != nil {
+		return nil, SearchOutput{}, err
+	}
+	output.Corpus = corpus
+
+	return searchResult(output), output, nil
+}
@@ -9,7 +9,7 @@ import (
 var searchTdsRnDocs = &mcp.Tool{
 	Name:        "search_tds_rn_docs",
 	Title:       "Search TDS React Native Documents",
-	Description: "Search TDS (Toss Design System) React Native documentation using full-text search. Returns matching documents ranked by relevance. Per-field relevance weights can be tuned via the optional *_boost parameters (defaults: title=5.0, description=1.5, content=1.0, category=1.0).",
+	Description: "Search TDS (Toss Design System) React Native documentation using full-text search. Returns matching documents ranked by relevance. Per-field relevance weights can be tuned via the optional *_boost parameters (defaults: title=5.0, description=1.5, content=1.0, category=1.0). Set syntax=true for phrases, -exclusions, field:term scoping and OR. Set profile (component, error_message, api_reference, guide, auto) instead of hand-tuning boosts.",
 	Annotations: &mcp.ToolAnnotations{
 		Title:          "Search TDS React Native Documents",
 		ReadOnlyHint:   true,
@@ -18,18 +18,16 @@ var searchTdsRnDocs = &mcp.Tool{
 }
 
 func (p *Protocol) searchTdsRnDocsHandler(ctx context.Context, r *mcp.CallToolRequest, input SearchInput) (result *mcp.CallToolResult, output SearchOutput, err error) {
-	searcher, err := p.tdsRn.get(ctx)
+	searcher, release, err := p.tdsRn.get(ctx)
 	if err != nil {
 		return nil, SearchOutput{}, err
 	}
+	defer release()
 
-	results, err := searcher.Search(ctx, input.Query, input.searchOptions())
+	output, err = searchTranslated(ctx, r, searcher, input)
 	if err != nil {
 		return nil, SearchOutput{}, err
 	}
 
-	return nil, SearchOutput{
-		Results: results,
-		Total:   len(results),
-	}, nil
+	return searchResult(output), output, nil
 }
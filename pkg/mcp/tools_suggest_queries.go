@@ -0,0 +1,55 @@
+package mcp
+
+import (
+	"context"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+var suggestQueries = &mcp.Tool{
+	Name:        "suggest_queries",
+	Title:       "Suggest Search Queries",
+	Description: "Complete a partial query and correct misspellings using terms that actually appear in the documentation index. Use this for search-as-you-type, or when a component or API name returned nothing and you are unsure of its exact spelling.",
+	Annotations: &mcp.ToolAnnotations{
+		Title:          "Suggest Search Queries",
+		ReadOnlyHint:   true,
+		IdempotentHint: true,
+	},
+}
+
+func (p *Protocol) suggestQueriesHandler(ctx context.Context, r *mcp.CallToolRequest, input SuggestQueriesInput) (result *mcp.CallToolResult, output SuggestQueriesOutput, err error) {
+	ls, err := p.searcherFor(input.Corpus)
+	if err != nil {
+		return nil, SuggestQueriesOutput{}, err
+	}
+
+	searcher, release, err := ls.get(ctx)
+	if err != nil {
+		return nil, SuggestQueriesOutput{}, err
+	}
+	defer release()
+
+	completions, err := searcher.Complete(ctx, input.Query, input.Limit)
+	if err != nil {
+		return nil, SuggestQueriesOutput{}, err
+	}
+
+	corrections, err := searcher.Suggest(ctx, input.Query, input.Limit)
+	if err != nil {
+		return nil, SuggestQueriesOutput{}, err
+	}
+
+	output = SuggestQueriesOutput{
+		Completions: nonNil(completions),
+		Corrections: nonNil(corrections),
+	}
+	return suggestionsResult(output), output, nil
+}
+
+// nonNil은 JSON에서 null 대신 빈 배열이 되도록 nil 슬라이스를 빈 슬라이스로 바꿉니다
+func nonNil[T any](values []T) []T {
+	if values == nil {
+		return []T{}
+	}
+	return values
+}
@@ -0,0 +1,26 @@
+package mcp
+
+import (
+	"context"
+	"testing"
+)
+
+func TestSuggestQueriesHandler_EmptyIndex(t *testing.T) {
+	p := &Protocol{docSearcher: newLazySearcher(fakeSearcher)}
+
+	_, output, err := p.suggestQueriesHandler(context.Background(), nil, SuggestQueriesInput{Query: "Buton"})
+	if err != nil {
+		t.Fatalf("suggest_queries failed: %v", err)
+	}
+	if output.Completions == nil || output.Corrections == nil {
+		t.Errorf("Expected empty arrays instead of null, got %+v", output)
+	}
+}
+
+func TestSuggestQueriesHandler_UnknownCorpus(t *testing.T) {
+	p := &Protocol{docSearcher: newLazySearcher(fakeSearcher)}
+
+	if _, _, err := p.suggestQueriesHandler(context.Background(), nil, SuggestQueriesInput{Query: "Buton", Corpus: "unity"}); err == nil {
+		t.Error("Expected an error for an unknown corpus")
+	}
+}
@@ -0,0 +1,204 @@
+package mcp
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/toss/apps-in-toss-ax/pkg/search"
+)
+
+const (
+	// translateTimeout은 클라이언트 모델의 번역을 기다리는 최대 시간입니다. 넘기면 원래 쿼리로만 검색합니다.
+	translateTimeout = 15 * time.Second
+	// translateMaxTokens는 번역 응답의 최대 토큰 수입니다. 키워드 몇 개면 충분합니다.
+	translateMaxTokens = 64
+)
+
+const translateSystemPrompt = `You turn search queries into Korean search keywords for a Korean documentation index (AppsInToss mini-app platform and the Toss Design System).
+Reply with only 2-5 concise Korean keywords separated by spaces, on one line, with no explanation or quotes.
+Keep code identifiers, component names and API names (e.g. Button, appLogin, useToast) unchanged.`
+
+// needsTranslation은 query가 한글 없이 다른 언어의 문장으로 된 검색어인지 확인합니다.
+// 문서는 모두 한국어이므로 이런 쿼리는 번역하면 결과가 좋아집니다. 단어 하나짜리 쿼리는
+// Button이나 appLogin 같은 식별자일 가능성이 높아 번역하지 않습니다.
+func needsTranslation(query string) bool {
+	words := 0
+	for _, field := range strings.Fields(query) {
+		letters := false
+		for _, r := range field {
+			if unicode.Is(unicode.Hangul, r) {
+				return false
+			}
+			letters = letters || unicode.IsLetter(r)
+		}
+		if letters {
+			words++
+		}
+	}
+	return words >= 2
+}
+
+// supportsSampling은 요청을 보낸 클라이언트가 sampling/createMessage를 지원하는지 확인합니다
+func supportsSampling(req *mcp.CallToolRequest) (*mcp.ServerSession, bool) {
+	if req == nil || req.Session == nil {
+		return nil, false
+	}
+	params := req.Session.InitializeParams()
+	if params == nil || params.Capabilities == nil || params.Capabilities.Sampling == nil {
+		return nil, false
+	}
+	return req.Session, true
+}
+
+// translateQuery는 클라이언트가 sampling을 지원하고 query가 한국어가 아니면 클라이언트 모델에
+// 한국어 검색어를 요청합니다. 번역할 필요가 없거나 번역에 실패하면 빈 문자열을 반환합니다.
+func translateQuery(ctx context.Context, req *mcp.CallToolRequest, input SearchInput) string {
+	if input.Syntax || !needsTranslation(input.Query) {
+		return ""
+	}
+	session, ok := supportsSampling(req)
+	if !ok {
+		return ""
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, translateTimeout)
+	defer cancel()
+	result, err := session.CreateMessage(ctx, &mcp.CreateMessageParams{
+		SystemPrompt: translateSystemPrompt,
+		Messages: []*mcp.SamplingMessage{{
+			Role:    "user",
+			Content: &mcp.TextContent{Text: input.Query},
+		}},
+		MaxTokens: translateMaxTokens,
+	})
+	if err != nil || result == nil {
+		return ""
+	}
+	text, ok := result.Content.(*mcp.TextContent)
+	if !ok {
+		return ""
+	}
+
+	translated, _, _ := strings.Cut(strings.TrimSpace(text.Text), "\n")
+	translated = strings.Trim(strings.TrimSpace(translated), "\"'`")
+	if translated == "" || strings.EqualFold(translated, input.Query) {
+		return ""
+	}
+	return translated
+}
+
+// searchTranslated는 input으로 검색하고, 한국어가 아닌 쿼리는 클라이언트 모델이 번역한 한국어 검색어로도
+// 검색해 결과를 합칩니다
+func searchTranslated(ctx context.Context, req *mcp.CallToolRequest, searcher *search.Searcher, input SearchInput) (SearchOutput, error) {
+	output, err := input.search(ctx, searcher)
+	if err != nil {
+		return SearchOutput{}, err
+	}
+
+	translated := translateQuery(ctx, req, input)
+	if translated == "" {
+		return output, nil
+	}
+	translatedInput := input
+	translatedInput.Query = translated
+	translatedOutput, err := translatedInput.search(ctx, searcher)
+	if err != nil {
+		// 번역한 쿼리는 보조 검색이므로 실패해도 원래 결과를 반환합니다
+		return output, nil
+	}
+
+	merged := mergeSearchOutputs(output, translatedOutput, input.searchOptions().Limit)
+	merged.TranslatedQuery = translated
+	return merged, nil
+}
+
+// rrfK는 reciprocal rank fusion에서 순위 차이를 완만하게 하는 상수입니다 (보통 60을 씁니다)
+const rrfK = 60
+
+// mergeSearchOutputs는 원래 쿼리와 번역한 쿼리의 결과를 문서 ID로 합쳐 limit개를 반환합니다.
+// 두 쿼리의 BM25 점수는 서로 비교할 수 없으므로 각 결과 목록의 순위로 reciprocal rank fusion 점수를 매겨
+// 정렬하며, 같으면 문서 언어로 검색한 번역 쿼리 쪽을 앞에 둡니다. 결과의 Score는 원래 검색 점수 그대로 둡니다.
+// 카테고리별 개수도 번역 쿼리 쪽을 우선합니다. Profile과 Relaxation은 합친 결과에 맞게 다시 정합니다.
+func mergeSearchOutputs(original, translated SearchOutput, limit int) SearchOutput {
+	byID := map[string]int{}
+	var results []search.SearchResult
+	var fused []float64
+	for _, list := range [][]search.SearchResult{translated.Results, original.Results} {
+		for rank, result := range list {
+			score := 1 / float64(rrfK+rank+1)
+			if i, ok := byID[result.ID]; ok {
+				fused[i] += score
+				if results[i].Relaxation != "" && result.Relaxation == "" {
+					results[i] = result
+				}
+				continue
+			}
+			byID[result.ID] = len(results)
+			results = append(results, result)
+			fused = append(fused, score)
+		}
+	}
+	// 완화하지 않은 검색으로 찾은 결과를 먼저 두고, 그 안에서는 합친 순위 점수순으로 정렬합니다
+	order := make([]int, len(results))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		i, j := order[a], order[b]
+		if (results[i].Relaxation == "") != (results[j].Relaxation == "") {
+			return results[i].Relaxation == ""
+		}
+		return fused[i] > fused[j]
+	})
+	sorted := make([]search.SearchResult, len(order))
+	for k, i := range order {
+		sorted[k] = results[i]
+	}
+	results = sorted
+	if len(results) > limit {
+		results = results[:limit]
+	}
+
+	merged := original
+	merged.Results = results
+	merged.Total = len(results)
+	// 두 쿼리가 auto로 서로 다른 프로필을 골랐다면 둘 다 적용된 것이므로 함께 표시합니다
+	merged.Profile = joinDistinct([]string{translated.Profile, original.Profile})
+	merged.Relaxation = mergedRelaxation(results)
+	if len(translated.Facets) > 0 {
+		merged.Facets = translated.Facets
+	}
+	if merged.Total > 0 && merged.Relaxation == "" {
+		merged.Suggestions = nil
+	}
+	return merged
+}
+
+// mergedRelaxation은 합친 결과가 모두 완화한 검색에서 나왔을 때 그 방식들을 반환합니다.
+// 완화하지 않은 결과가 하나라도 있으면 근사 결과가 아니므로 빈 문자열입니다.
+func mergedRelaxation(results []search.SearchResult) string {
+	relaxations := make([]string, 0, len(results))
+	for _, result := range results {
+		if result.Relaxation == "" {
+			return ""
+		}
+		relaxations = append(relaxations, result.Relaxation)
+	}
+	return joinDistinct(relaxations)
+}
+
+// joinDistinct는 values에서 빈 값과 중복을 뺀 뒤 처음 나온 순서대로 ", "로 잇습니다
+func joinDistinct(values []string) string {
+	var distinct []string
+	for _, v := range values {
+		if v != "" && !slices.Contains(distinct, v) {
+			distinct = append(distinct, v)
+		}
+	}
+	return strings.Join(distinct, ", ")
+}
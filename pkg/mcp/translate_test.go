@@ -0,0 +1,151 @@
+package mcp
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/toss/apps-in-toss-ax/pkg/search"
+)
+
+func TestNeedsTranslation(t *testing.T) {
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{"how to integrate payments", true},
+		{"Button", false},
+		{"appLogin", false},
+		{"결제 연동", false},
+		{"Button 사용법", false},
+		{"支付 集成", true},
+		{"404 500", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := needsTranslation(tt.query); got != tt.want {
+			t.Errorf("needsTranslation(%q) = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestMergeSearchOutputs(t *testing.T) {
+	original := SearchOutput{
+		Results: []search.SearchResult{
+			{ID: "a", Score: 0.5},
+			{ID: "b", Score: 0.2, Relaxation: "fuzzy"},
+		},
+		Suggestions: []string{"payment"},
+	}
+	translated := SearchOutput{
+		Results: []search.SearchResult{
+			{ID: "a", Score: 0.9},
+			{ID: "c", Score: 0.3},
+		},
+		Facets: []search.CategoryFacet{{Category: "결제", Count: 2}},
+	}
+
+	merged := mergeSearchOutputs(original, translated, 2)
+	if merged.Total != 2 || merged.Results[0].ID != "a" || merged.Results[0].Score != 0.9 || merged.Results[1].ID != "c" {
+		t.Errorf("Unexpected merged results %+v", merged.Results)
+	}
+	if len(merged.Facets) != 1 || merged.Suggestions != nil || merged.Relaxation != "" {
+		t.Errorf("Unexpected merged metadata %+v", merged)
+	}
+}
+
+func TestMergeSearchOutputs_RecomputesProfileAndRelaxation(t *testing.T) {
+	original := SearchOutput{
+		Profile:    search.ProfileAPIReference,
+		Relaxation: search.RelaxFuzzyTitle,
+		Results:    []search.SearchResult{{ID: "a", Relaxation: search.RelaxFuzzyTitle}},
+	}
+	translated := SearchOutput{
+		Profile: search.ProfileGuide,
+		Results: []search.SearchResult{{ID: "b"}},
+	}
+
+	merged := mergeSearchOutputs(original, translated, 5)
+	if merged.Profile != "guide, api_reference" || merged.Relaxation != "" {
+		t.Errorf("Expected both profiles and no relaxation, got profile %q relaxation %q", merged.Profile, merged.Relaxation)
+	}
+
+	translated = SearchOutput{
+		Profile: search.ProfileAPIReference,
+		Results: []search.SearchResult{{ID: "b", Relaxation: search.RelaxPrefix}},
+	}
+	merged = mergeSearchOutputs(original, translated, 5)
+	if merged.Profile != search.ProfileAPIReference || merged.Relaxation != "prefix, fuzzy_title" {
+		t.Errorf("Expected one profile and both relaxations, got profile %q relaxation %q", merged.Profile, merged.Relaxation)
+	}
+}
+
+func TestMergeSearchOutputs_RanksAcrossScoreScales(t *testing.T) {
+	// 원래 쿼리는 드문 단어가 많아 점수가 훨씬 크지만, 순위로 합치므로 두 목록이 번갈아 나와야 함
+	original := SearchOutput{Results: []search.SearchResult{
+		{ID: "x", Score: 100}, {ID: "y", Score: 90}, {ID: "z", Score: 80},
+	}}
+	translated := SearchOutput{Results: []search.SearchResult{
+		{ID: "p", Score: 0.9}, {ID: "q", Score: 0.8},
+	}}
+
+	merged := mergeSearchOutputs(original, translated, 4)
+	var ids []string
+	for _, r := range merged.Results {
+		ids = append(ids, r.ID)
+	}
+	if got := strings.Join(ids, ","); got != "p,x,q,y" {
+		t.Errorf("Expected rank-interleaved results p,x,q,y, got %s", got)
+	}
+}
+
+func TestSearchDocs_TranslatesWithSampling(t *testing.T) {
+	p := New()
+	p.OnInit = func(context.Context) {}
+	p.docSearcher = newLazySearcher(fakeSearcher)
+
+	ctx := context.Background()
+	serverTransport, clientTransport := mcpsdk.NewInMemoryTransports()
+	serverSession, err := p.Server.Connect(ctx, serverTransport, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer serverSession.Close()
+
+	var sampled string
+	client := mcpsdk.NewClient(&mcpsdk.Implementation{Name: "test", Version: "0.0.0"}, &mcpsdk.ClientOptions{
+		CreateMessageHandler: func(_ context.Context, req *mcpsdk.CreateMessageRequest) (*mcpsdk.CreateMessageResult, error) {
+			sampled = req.Params.Messages[0].Content.(*mcpsdk.TextContent).Text
+			return &mcpsdk.CreateMessageResult{Content: &mcpsdk.TextContent{Text: "결제 연동\n"}, Model: "test", Role: "assistant"}, nil
+		},
+	})
+	session, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer session.Close()
+
+	result, err := session.CallTool(ctx, &mcpsdk.CallToolParams{Name: searchDocs.Name, Arguments: map[string]any{"query": "how to integrate payments"}})
+	if err != nil || result.IsError {
+		t.Fatalf("CallTool failed: %v %+v", err, result)
+	}
+	if sampled != "how to integrate payments" {
+		t.Errorf("Expected the query to be sent for translation, got %q", sampled)
+	}
+	if structured, _ := result.StructuredContent.(map[string]any); structured["translated_query"] != "결제 연동" {
+		t.Errorf("Expected translated_query in the output, got %v", result.StructuredContent)
+	}
+	if text := result.Content[0].(*mcpsdk.TextContent).Text; !strings.Contains(text, "`결제 연동`") {
+		t.Errorf("Expected the translated query in the text, got %q", text)
+	}
+
+	// 한국어 쿼리는 번역하지 않아야 함
+	sampled = ""
+	if _, err := session.CallTool(ctx, &mcpsdk.CallToolParams{Name: searchDocs.Name, Arguments: map[string]any{"query": "결제 연동"}}); err != nil {
+		t.Fatal(err)
+	}
+	if sampled != "" {
+		t.Errorf("Expected no sampling for a Korean query, got %q", sampled)
+	}
+}
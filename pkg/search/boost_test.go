@@ -46,6 +46,7 @@ func TestFieldBoostsValidate(t *testing.T) {
 		{Title: 3.0},                             // 나머지 0은 허용 (하나라도 양수면 됨)
 		{Title: 0, Description: 0, Content: 1.0}, // 개별 0 허용
 		{Title: MaxFieldBoost, Content: 1.0},     // 상한값은 허용
+		{Title: 1.0, Links: 0},                   // 링크 가중치 0은 비활성화
 	}
 	for _, fb := range valid {
 		if err := fb.validate(); err != nil {
@@ -60,6 +61,8 @@ func TestFieldBoostsValidate(t *testing.T) {
 		{Title: math.Inf(1), Content: 1.0}, // +Inf도 점수를 NaN으로 오염시킴
 		{Title: 1e308, Content: 1.0},       // 유한해도 과대하면 bleve 가중치 계산이 오버플로됨
 		{Title: MaxFieldBoost + 1},         // 상한 초과
+		{Title: 1.0, Links: -0.1},          // 링크 가중치도 음수는 거부
+		{Title: 1.0, Links: math.NaN()},    // 링크 가중치 NaN
 	}
 	for _, fb := range invalid {
 		if err := fb.validate(); err == nil {
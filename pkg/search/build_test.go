@@ -0,0 +1,229 @@
+package search
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// buildHelperEnv가 설정되면 TestBuildIndexHelperProcess가 별도 프로세스의 인덱스 빌더로 동작합니다
+const buildHelperEnv = "AX_TEST_BUILD_INDEX_PATH"
+
+const (
+	stressBuilders      = 4
+	stressBuildRounds   = 3
+	stressDocumentCount = 50
+)
+
+func stressDocuments() []IndexDocument {
+	docs := make([]IndexDocument, stressDocumentCount)
+	for i := range docs {
+		docs[i] = IndexDocument{
+			ID:      fmt.Sprintf("doc-%d", i),
+			Title:   fmt.Sprintf("결제 문서 %d", i),
+			Content: "토스페이 결제를 연동하는 방법입니다.",
+			URL:     fmt.Sprintf("https://example.com/doc-%d", i),
+		}
+	}
+	return docs
+}
+
+func TestBuildIndexHelperProcess(t *testing.T) {
+	indexPath := os.Getenv(buildHelperEnv)
+	if indexPath == "" {
+		t.Skip("helper process for TestBuildIndex_ConcurrentProcesses")
+	}
+
+	im := NewIndexManager(indexPath)
+	defer im.Close()
+
+	for range stressBuildRounds {
+		if err := im.BuildIndex(stressDocuments()); err != nil {
+			t.Fatalf("BuildIndex failed: %v", err)
+		}
+	}
+}
+
+func TestBuildIndex_ConcurrentProcesses(t *testing.T) {
+	tempDir := t.TempDir()
+	indexPath := filepath.Join(tempDir, "search-index")
+
+	// 리더가 항상 열 수 있는 인덱스가 있도록 먼저 한 번 빌드해 둔다
+	initial := NewIndexManager(indexPath)
+	if err := initial.BuildIndex(stressDocuments()); err != nil {
+		t.Fatalf("Initial BuildIndex failed: %v", err)
+	}
+	initial.Close()
+
+	var done atomic.Bool
+	var readerWG sync.WaitGroup
+	var reads atomic.Int32
+	readerErrs := make(chan error, 8)
+
+	for range 2 {
+		readerWG.Add(1)
+		go func() {
+			defer readerWG.Done()
+			for !done.Load() {
+				im := NewIndexManager(indexPath)
+				if err := im.OpenIndex(); err != nil {
+					readerErrs <- fmt.Errorf("OpenIndex during rebuild: %w", err)
+					return
+				}
+				count, err := im.index.DocCount()
+				im.Close()
+				if err != nil {
+					readerErrs <- err
+					return
+				}
+				if count != stressDocumentCount {
+					readerErrs <- fmt.Errorf("reader saw %d documents, want %d", count, stressDocumentCount)
+					return
+				}
+				reads.Add(1)
+			}
+		}()
+	}
+
+	var builderWG sync.WaitGroup
+	builderErrs := make([]error, stressBuilders)
+	builderOutputs := make([][]byte, stressBuilders)
+	for i := range stressBuilders {
+		builderWG.Add(1)
+		go func(idx int) {
+			defer builderWG.Done()
+			cmd := exec.Command(os.Args[0], "-test.run=^TestBuildIndexHelperProcess$")
+			cmd.Env = append(os.Environ(), buildHelperEnv+"="+indexPath)
+			builderOutputs[idx], builderErrs[idx] = cmd.CombinedOutput()
+		}(i)
+	}
+
+	builderWG.Wait()
+	done.Store(true)
+	readerWG.Wait()
+	close(readerErrs)
+
+	for i, err := range builderErrs {
+		if err != nil {
+			t.Errorf("builder %d failed: %v\n%s", i, err, builderOutputs[i])
+		}
+	}
+	for err := range readerErrs {
+		t.Error(err)
+	}
+	if reads.Load() == 0 {
+		t.Error("Expected readers to open the index at least once")
+	}
+
+	final := NewIndexManager(indexPath)
+	if err := final.OpenIndex(); err != nil {
+		t.Fatalf("OpenIndex after builds failed: %v", err)
+	}
+	defer final.Close()
+
+	results, _, err := final.Search("결제", stressDocumentCount)
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(results) != stressDocumentCount {
+		t.Errorf("Expected %d results, got %d", stressDocumentCount, len(results))
+	}
+
+	// 교체가 끝난 뒤에는 임시 디렉터리가 남지 않아야 한다
+	entries, err := os.ReadDir(tempDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		if strings.Contains(entry.Name(), indexStagingSuffix) || strings.Contains(entry.Name(), indexRetiredSuffix) {
+			t.Errorf("Unexpected leftover directory: %s", entry.Name())
+		}
+	}
+}
+
+func TestBuildIndex_KeepsPreviousIndexOnFailure(t *testing.T) {
+	indexPath := filepath.Join(t.TempDir(), "search-index")
+
+	im := NewIndexManager(indexPath)
+	if err := im.BuildIndex(stressDocuments()); err != nil {
+		t.Fatalf("BuildIndex failed: %v", err)
+	}
+	im.Close()
+
+	// ID가 빈 문서는 bleve 배치에서 거부되므로 빌드가 실패한다
+	broken := NewIndexManager(indexPath)
+	if err := broken.BuildIndex([]IndexDocument{{ID: "", Title: "깨진 문서"}}); err == nil {
+		t.Fatal("Expected BuildIndex to fail for empty document ID")
+	}
+
+	if err := broken.OpenIndex(); err != nil {
+		t.Fatalf("OpenIndex after failed build: %v", err)
+	}
+	defer broken.Close()
+
+	count, err := broken.index.DocCount()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != stressDocumentCount {
+		t.Errorf("Expected previous index with %d documents, got %d", stressDocumentCount, count)
+	}
+}
+
+func TestBuildIndex_KeepsRetiredIndexWhileOpen(t *testing.T) {
+	tempDir := t.TempDir()
+	indexPath := filepath.Join(tempDir, "search-index")
+
+	builder := NewIndexManager(indexPath)
+	if err := builder.BuildIndex(stressDocuments()); err != nil {
+		t.Fatalf("BuildIndex failed: %v", err)
+	}
+	builder.Close()
+
+	reader := NewIndexManager(indexPath)
+	if err := reader.OpenIndex(); err != nil {
+		t.Fatalf("OpenIndex failed: %v", err)
+	}
+
+	if err := builder.BuildIndex(stressDocuments()); err != nil {
+		t.Fatalf("Rebuild failed: %v", err)
+	}
+	defer builder.Close()
+
+	retired := func() []string {
+		entries, err := os.ReadDir(tempDir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var names []string
+		for _, entry := range entries {
+			if strings.Contains(entry.Name(), indexRetiredSuffix) {
+				names = append(names, entry.Name())
+			}
+		}
+		return names
+	}
+
+	if got := retired(); len(got) != 1 {
+		t.Fatalf("Expected the open index to be kept aside, got %v", got)
+	}
+	if count, err := reader.index.DocCount(); err != nil || count != stressDocumentCount {
+		t.Errorf("Expected the reader to keep using the retired index, got %d, %v", count, err)
+	}
+
+	reader.Close()
+	next := NewIndexManager(indexPath)
+	if err := next.OpenIndex(); err != nil {
+		t.Fatalf("OpenIndex failed: %v", err)
+	}
+	defer next.Close()
+
+	if got := retired(); len(got) != 0 {
+		t.Errorf("Expected the retired index to be removed once closed, got %v", got)
+	}
+}
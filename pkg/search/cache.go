@@ -22,12 +22,24 @@ type CacheMetadata struct {
 	ETag        string `json:"etag"`
 	LastFetched string `json:"last_fetched"`
 	URL         string `json:"url"`
+
+	// SchemaVersion은 캐시된 인덱스를 만들 때 사용한 매핑 버전입니다 (IndexSchemaVersion)
+	SchemaVersion int `json:"schema_version"`
+
+	// LastValidated는 원본의 변경 여부를 네트워크로 마지막으로 확인한 시각입니다 (RFC3339)
+	LastValidated string `json:"last_validated,omitempty"`
+	// LastCheckFailed는 원본 확인이 네트워크 오류로 마지막으로 실패한 시각입니다 (RFC3339)
+	LastCheckFailed string `json:"last_check_failed,omitempty"`
+	// FreshnessTTLSeconds는 LastValidated 이후 원본 확인을 건너뛰는 시간(초)입니다.
+	// 0이면 DefaultFreshnessTTL을 사용합니다.
+	FreshnessTTLSeconds int `json:"freshness_ttl_seconds,omitempty"`
 }
 
 type CacheManager struct {
 	cacheDir     string
 	metadataPath string
 	indexPath    string
+	sourceDir    string
 }
 
 // CacheConfig는 CacheManager 설정입니다
@@ -60,6 +72,7 @@ func NewCacheManagerWithConfig(config CacheConfig) (*CacheManager, error) {
 		cacheDir:     cacheDir,
 		metadataPath: filepath.Join(cacheDir, config.MetadataFileName),
 		indexPath:    filepath.Join(cacheDir, config.IndexSubDir),
+		sourceDir:    filepath.Join(cacheDir, config.IndexSubDir+sourceDirSuffix),
 	}, nil
 }
 
@@ -67,36 +80,90 @@ func (cm *CacheManager) IndexPath() string {
 	return cm.indexPath
 }
 
-func (cm *CacheManager) GetCachedETag() (string, error) {
+// LoadMetadata는 저장된 캐시 메타데이터를 읽습니다.
+// 메타데이터가 없거나 손상된 경우 빈 메타데이터를 반환합니다.
+func (cm *CacheManager) LoadMetadata() (CacheMetadata, error) {
 	data, err := os.ReadFile(cm.metadataPath)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return "", nil
+			return CacheMetadata{}, nil
 		}
-		return "", err
+		return CacheMetadata{}, err
 	}
 
 	var metadata CacheMetadata
 	if err := json.Unmarshal(data, &metadata); err != nil {
-		return "", nil
+		return CacheMetadata{}, nil
+	}
+
+	return metadata, nil
+}
+
+func (cm *CacheManager) GetCachedETag() (string, error) {
+	metadata, err := cm.LoadMetadata()
+	if err != nil {
+		return "", err
 	}
 
 	return metadata.ETag, nil
 }
 
+// SaveETag는 인덱스를 만든 원본의 ETag와 현재 스키마 버전을 기록합니다.
+// 신선도 설정과 확인 기록은 그대로 유지합니다.
 func (cm *CacheManager) SaveETag(url, etag string) error {
-	metadata := CacheMetadata{
-		ETag:        etag,
-		LastFetched: time.Now().UTC().Format(time.RFC3339),
-		URL:         url,
+	return cm.updateMetadata(func(metadata *CacheMetadata) {
+		metadata.ETag = etag
+		metadata.LastFetched = time.Now().UTC().Format(time.RFC3339)
+		metadata.URL = url
+		metadata.SchemaVersion = IndexSchemaVersion
+	})
+}
+
+// updateMetadata는 저장된 메타데이터를 읽어 update를 적용한 뒤 다시 저장합니다
+func (cm *CacheManager) updateMetadata(update func(*CacheMetadata)) error {
+	metadata, err := cm.LoadMetadata()
+	if err != nil {
+		return err
 	}
 
+	update(&metadata)
+
 	data, err := json.MarshalIndent(metadata, "", "  ")
 	if err != nil {
 		return err
 	}
 
-	return os.WriteFile(cm.metadataPath, data, 0644)
+	return writeFileAtomic(cm.metadataPath, data)
+}
+
+// writeFileAtomic은 임시 파일에 쓴 뒤 rename하여, 동시에 읽는 다른 프로세스가
+// 쓰다 만 파일을 보지 않도록 합니다
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Chmod(tmpPath, 0644); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	return nil
 }
 
 func (cm *CacheManager) CheckETag(ctx context.Context, url string) (etag string, changed bool, err error) {
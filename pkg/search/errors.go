@@ -0,0 +1,70 @@
+package search
+
+import (
+	"errors"
+
+	"github.com/toss/apps-in-toss-ax/internal/httputil"
+)
+
+// ErrorCode는 호출자(MCP 클라이언트의 모델, CLI를 실행한 스크립트)가 에러에 대응할 수 있도록 분류한 코드입니다
+type ErrorCode string
+
+const (
+	// ErrorNotFound는 문서나 문서 안의 절을 찾지 못했음을 나타냅니다
+	ErrorNotFound ErrorCode = "not_found"
+	// ErrorInvalidArgument는 부스트 값, 프로필 이름, 검색 문법 같은 입력이 잘못되었음을 나타냅니다
+	ErrorInvalidArgument ErrorCode = "invalid_argument"
+	// ErrorIndexUnavailable은 검색 인덱스를 준비하지 못했음을 나타냅니다
+	ErrorIndexUnavailable ErrorCode = "index_unavailable"
+	// ErrorNetwork는 문서 원본을 내려받지 못했음을 나타냅니다
+	ErrorNetwork ErrorCode = "network"
+	// ErrorInternal은 위로 분류되지 않은 에러입니다
+	ErrorInternal ErrorCode = "internal"
+)
+
+var (
+	// ErrInvalidArgument는 잘못된 입력으로 인한 에러를 분류합니다. InvalidArgument로 감쌉니다.
+	ErrInvalidArgument = errors.New("invalid argument")
+	// ErrIndexUnavailable은 인덱스를 준비하지 못한 에러를 분류합니다. EnsureIndex가 반환하는 에러는 모두 이것으로 감쌉니다.
+	ErrIndexUnavailable = errors.New("search index unavailable")
+)
+
+// classifiedError는 원래 메시지를 그대로 두고 errors.Is로 분류 에러를 확인할 수 있게 합니다
+type classifiedError struct {
+	err  error
+	kind error
+}
+
+func (e *classifiedError) Error() string { return e.err.Error() }
+
+func (e *classifiedError) Unwrap() []error { return []error{e.err, e.kind} }
+
+// InvalidArgument는 err를 ErrInvalidArgument로 분류합니다. 메시지는 바뀌지 않습니다.
+func InvalidArgument(err error) error {
+	return &classifiedError{err: err, kind: ErrInvalidArgument}
+}
+
+// IndexUnavailable은 err를 ErrIndexUnavailable로 분류합니다. 메시지는 바뀌지 않습니다.
+func IndexUnavailable(err error) error {
+	return &classifiedError{err: err, kind: ErrIndexUnavailable}
+}
+
+// CodeOf는 err의 ErrorCode를 반환합니다. 인덱스를 준비하다 네트워크 요청이 실패한 경우는 ErrorNetwork입니다.
+func CodeOf(err error) ErrorCode {
+	var fetchErr *httputil.FetchError
+	var syntaxErr *QuerySyntaxError
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, ErrDocumentNotFound), errors.Is(err, ErrSectionNotFound):
+		return ErrorNotFound
+	case errors.Is(err, ErrInvalidArgument), errors.Is(err, ErrInvalidLineRange), errors.As(err, &syntaxErr):
+		return ErrorInvalidArgument
+	case errors.As(err, &fetchErr):
+		return ErrorNetwork
+	case errors.Is(err, ErrIndexUnavailable):
+		return ErrorIndexUnavailable
+	default:
+		return ErrorInternal
+	}
+}
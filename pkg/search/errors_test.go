@@ -0,0 +1,43 @@
+package search
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/toss/apps-in-toss-ax/internal/httputil"
+)
+
+func TestCodeOf(t *testing.T) {
+	fetchErr := &httputil.FetchError{URL: "https://developers-apps-in-toss.toss.im/llms-full.txt", StatusCode: 503}
+
+	tests := []struct {
+		name string
+		err  error
+		want ErrorCode
+	}{
+		{"nil", nil, ""},
+		{"document", fmt.Errorf("%w: abc", ErrDocumentNotFound), ErrorNotFound},
+		{"section", fmt.Errorf("%w: 에러 코드", ErrSectionNotFound), ErrorNotFound},
+		{"boost", FieldBoosts{Title: -1, Content: 1}.validate(), ErrorInvalidArgument},
+		{"syntax", &QuerySyntaxError{Query: `"a`, Message: "unterminated phrase"}, ErrorInvalidArgument},
+		{"line range", fmt.Errorf("%w: 5-3", ErrInvalidLineRange), ErrorInvalidArgument},
+		{"network while indexing", IndexUnavailable(fmt.Errorf("build: %w", fetchErr)), ErrorNetwork},
+		{"index", IndexUnavailable(errors.New("index is corrupted")), ErrorIndexUnavailable},
+		{"other", errors.New("boom"), ErrorInternal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CodeOf(tt.err); got != tt.want {
+				t.Errorf("CodeOf(%v) = %q, want %q", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInvalidArgument_KeepsMessage(t *testing.T) {
+	err := InvalidArgument(errors.New("unknown corpus"))
+	if err.Error() != "unknown corpus" || !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("Expected the original message classified as invalid argument, got %v", err)
+	}
+}
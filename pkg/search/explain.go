@@ -0,0 +1,145 @@
+package search
+
+import (
+	"math"
+	"regexp"
+	"sort"
+
+	blevesearch "github.com/blevesearch/bleve/v2/search"
+)
+
+// ScoreExplanation은 검색 결과 하나의 점수가 어떻게 계산되었는지 필드별로 나눈 것입니다.
+// Score = FieldScore × LinkFactor이며, 역링크 보정을 하지 않았으면 LinkFactor는 생략됩니다.
+type ScoreExplanation struct {
+	// FieldScore는 필드별 점수의 합입니다 (역링크 보정 전 bleve 점수)
+	FieldScore float64      `json:"field_score"`
+	Fields     []FieldScore `json:"fields"`
+	// Backlinks는 이 문서를 링크하는 같은 인덱스의 문서 수입니다
+	Backlinks uint64 `json:"backlinks,omitempty"`
+	// LinkFactor는 역링크 수로 곱한 값입니다 (1 + link boost × ln(1 + backlinks))
+	LinkFactor float64 `json:"link_factor,omitempty"`
+}
+
+// FieldScore는 한 필드(title, description, content, category 등)가 점수에 더한 값과 매칭된 단어입니다
+type FieldScore struct {
+	Field string      `json:"field"`
+	Score float64     `json:"score"`
+	Terms []TermScore `json:"terms"`
+}
+
+// TermScore는 인덱스 단어 하나가 점수에 더한 값입니다.
+// Fuzzy는 쿼리에 없는 단어가 오타 허용이나 접두어 완화로 매칭된 경우입니다 (예: "navigetion" → "navigation").
+type TermScore struct {
+	Term  string  `json:"term"`
+	Score float64 `json:"score"`
+	Fuzzy bool    `json:"fuzzy,omitempty"`
+}
+
+// explainPrecision은 설명에 표시하는 점수의 소수점 자릿수입니다
+const explainPrecision = 1e4
+
+// termWeightPattern은 bleve 단어 점수 설명에서 필드와 단어를 꺼냅니다.
+// 예: "weight(title:결제^5.000000 in doc), product of:", "fieldWeight(content:결제 in doc), as per bm25 model, ..."
+var termWeightPattern = regexp.MustCompile(`^(?:weight|fieldWeight)\(([^:]+):(.+?)(?:\^[0-9.]+)? in `)
+
+// explainScore는 bleve 설명 트리를 필드별, 단어별 점수로 요약합니다.
+// queryTerms는 쿼리를 검색용 analyzer로 분석한 단어로, 여기에 없는 단어는 Fuzzy로 표시합니다.
+func explainScore(expl *blevesearch.Explanation, queryTerms map[string]bool) *ScoreExplanation {
+	fields := map[string]*FieldScore{}
+	var order []string
+
+	collectTermScores(expl, 1, func(field, term string, score float64) {
+		fs, ok := fields[field]
+		if !ok {
+			fs = &FieldScore{Field: field}
+			fields[field] = fs
+			order = append(order, field)
+		}
+		fs.Score += score
+		fs.Terms = append(fs.Terms, TermScore{Term: term, Score: roundScore(score), Fuzzy: !queryTerms[term]})
+	})
+
+	explanation := &ScoreExplanation{Fields: make([]FieldScore, 0, len(order))}
+	for _, field := range order {
+		fs := fields[field]
+		explanation.FieldScore += fs.Score
+		fs.Score = roundScore(fs.Score)
+		sort.SliceStable(fs.Terms, func(i, j int) bool {
+			return fs.Terms[i].Score > fs.Terms[j].Score
+		})
+		explanation.Fields = append(explanation.Fields, *fs)
+	}
+	explanation.FieldScore = roundScore(explanation.FieldScore)
+	sort.SliceStable(explanation.Fields, func(i, j int) bool {
+		return explanation.Fields[i].Score > explanation.Fields[j].Score
+	})
+	return explanation
+}
+
+// collectTermScores는 설명 트리에서 단어 점수 노드를 찾아, 상위 노드가 곱하는 값(coord 등)을 반영한 기여도를 add로 넘깁니다
+func collectTermScores(expl *blevesearch.Explanation, weight float64, add func(field, term string, score float64)) {
+	if expl == nil {
+		return
+	}
+	if m := termWeightPattern.FindStringSubmatch(expl.Message); m != nil {
+		add(m[1], m[2], expl.Value*weight)
+		return
+	}
+
+	if expl.Message != "product of:" {
+		for _, child := range expl.Children {
+			collectTermScores(child, weight, add)
+		}
+		return
+	}
+
+	for i, child := range expl.Children {
+		factor := weight
+		for j, sibling := range expl.Children {
+			if i != j && sibling != nil {
+				factor *= sibling.Value
+			}
+		}
+		collectTermScores(child, factor, add)
+	}
+}
+
+// queryTermSet은 text를 검색용 analyzer로 분석한 단어 집합입니다 (인덱스와 같이 앞 10글자로 자름)
+func (im *IndexManager) queryTermSet(text string) map[string]bool {
+	terms := map[string]bool{}
+	analyzer := im.index.Mapping().AnalyzerNamed("cjk_search")
+	if analyzer == nil {
+		return terms
+	}
+	for _, token := range analyzer.Analyze([]byte(text)) {
+		terms[indexedTerm(string(token.Term))] = true
+	}
+	return terms
+}
+
+func roundScore(v float64) float64 {
+	return math.Round(v*explainPrecision) / explainPrecision
+}
+
+// explainResults는 docs를 낸 검색 요청에서 받은 bleve 설명으로 각 결과의 점수 설명을 채웁니다.
+// 역링크 보정을 했다면(linkBoost > 0) 문서별 역링크 수와 곱한 값도 함께 기록합니다.
+func (s *Searcher) explainResults(results []SearchResult, docs []IndexDocument, text string, linkBoost float64) error {
+	var backlinks []uint64
+	if linkBoost > 0 {
+		var err error
+		if backlinks, err = s.indexManager.backlinkCounts(docs); err != nil {
+			return err
+		}
+	}
+
+	queryTerms := s.indexManager.queryTermSet(text)
+	for i := range results {
+		explanation := explainScore(docs[i].explanation, queryTerms)
+		if linkBoost > 0 {
+			explanation.Backlinks = backlinks[i]
+			explanation.LinkFactor = roundScore(1 + linkBoost*math.Log1p(float64(backlinks[i])))
+		}
+		results[i].Explanation = explanation
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package search
+
+import (
+	"context"
+	"math"
+	"os"
+	"testing"
+)
+
+func TestSearcher_SearchExplain(t *testing.T) {
+	s, tempDir := testSearcher(t, appsInTossIndexer, nil)
+	defer os.RemoveAll(tempDir)
+
+	if err := s.indexManager.CreateIndex(); err != nil {
+		t.Fatalf("Failed to create index: %v", err)
+	}
+	defer s.Close()
+
+	docs := []IndexDocument{
+		{ID: "payment", Title: "결제 연동", Description: "토스페이 결제", Content: "결제를 연동합니다.", Category: "결제", URL: "https://example.com/payment"},
+		{ID: "refund", Title: "환불", Content: "결제를 취소합니다. [결제 연동](https://example.com/payment)", URL: "https://example.com/refund"},
+		{ID: "navigation", Title: "Navigation", Content: "화면을 이동합니다.", URL: "https://example.com/navigation"},
+	}
+	if err := s.indexManager.IndexDocuments(docs); err != nil {
+		t.Fatalf("Failed to index: %v", err)
+	}
+
+	t.Run("field contributions add up to the score", func(t *testing.T) {
+		results, err := s.Search(context.Background(), "결제", &SearchOptions{Explain: true})
+		if err != nil {
+			t.Fatalf("Search failed: %v", err)
+		}
+		if len(results) != 2 || results[0].ID != "payment" {
+			t.Fatalf("Expected payment first of 2 results, got %+v", results)
+		}
+
+		for _, r := range results {
+			expl := r.Explanation
+			if expl == nil {
+				t.Fatalf("Expected explanation for %s", r.ID)
+			}
+			got := expl.FieldScore * expl.LinkFactor
+			if math.Abs(got-r.Score) > 1e-3 {
+				t.Errorf("%s: field score %v × link factor %v = %v, want score %v", r.ID, expl.FieldScore, expl.LinkFactor, got, r.Score)
+			}
+		}
+
+		fields := map[string]FieldScore{}
+		for _, f := range results[0].Explanation.Fields {
+			fields[f.Field] = f
+		}
+		for _, name := range []string{"title", "description", "content", "category"} {
+			if fields[name].Score <= 0 {
+				t.Errorf("Expected %s contribution for payment, got %+v", name, results[0].Explanation.Fields)
+			}
+		}
+		if results[0].Explanation.Fields[0].Field != "title" {
+			t.Errorf("Expected title to contribute most, got %+v", results[0].Explanation.Fields)
+		}
+		if results[0].Explanation.Backlinks != 1 {
+			t.Errorf("Expected 1 backlink for payment, got %d", results[0].Explanation.Backlinks)
+		}
+	})
+
+	t.Run("fuzzy matches are marked", func(t *testing.T) {
+		results, err := s.Search(context.Background(), "Navigetion", &SearchOptions{Explain: true})
+		if err != nil {
+			t.Fatalf("Search failed: %v", err)
+		}
+		if len(results) == 0 || results[0].Explanation == nil {
+			t.Fatalf("Expected explained results, got %+v", results)
+		}
+
+		fuzzy := false
+		for _, f := range results[0].Explanation.Fields {
+			for _, term := range f.Terms {
+				if term.Term == "navigation" && term.Fuzzy {
+					fuzzy = true
+				}
+			}
+		}
+		if !fuzzy {
+			t.Errorf("Expected a fuzzy match on navigation, got %+v", results[0].Explanation.Fields)
+		}
+	})
+
+	t.Run("no explanation by default", func(t *testing.T) {
+		results, err := s.Search(context.Background(), "결제", nil)
+		if err != nil {
+			t.Fatalf("Search failed: %v", err)
+		}
+		for _, r := range results {
+			if r.Explanation != nil {
+				t.Errorf("Expected no explanation, got %+v", r.Explanation)
+			}
+		}
+	})
+}
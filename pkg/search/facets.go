@@ -0,0 +1,101 @@
+package search
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/search/query"
+)
+
+const (
+	// categoryFacetField는 category 전체 경로를 하나의 단어로 색인한 키워드 하위 필드입니다
+	categoryFacetField = "category_keyword"
+
+	// 집계할 카테고리 경로의 최대 개수. 문서 모음의 전체 카테고리 수보다 충분히 크게 둡니다.
+	maxCategoryFacetTerms = 1000
+
+	// categorySeparator는 카테고리 경로의 단계 구분자입니다 ("Unity > 결제")
+	categorySeparator = " > "
+)
+
+// CategoryFacet은 검색 쿼리와 매칭되는 문서의 카테고리별 개수입니다.
+// 최상위 카테고리 아래에 두 번째 단계 카테고리("Unity > 결제")의 개수가 함께 들어갑니다.
+type CategoryFacet struct {
+	Category      string             `json:"category"`
+	Count         int                `json:"count"`
+	Subcategories []SubcategoryFacet `json:"subcategories,omitempty"`
+}
+
+// SubcategoryFacet은 두 번째 단계 카테고리의 개수입니다. Category는 최상위 카테고리를 포함한 경로입니다.
+// MCP 출력 스키마는 재귀 타입을 표현할 수 없으므로 CategoryFacet과 따로 둡니다.
+type SubcategoryFacet struct {
+	Category string `json:"category"`
+	Count    int    `json:"count"`
+}
+
+// categoryFacets는 searchQuery와 매칭되는 모든 문서(상위 limit개가 아닌)의 카테고리 개수를 많은 순서로 반환합니다.
+// 카테고리가 없는 문서는 세지 않습니다.
+func (im *IndexManager) categoryFacets(searchQuery query.Query) ([]CategoryFacet, error) {
+	request := bleve.NewSearchRequestOptions(searchQuery, 0, 0, false)
+	request.AddFacet(categoryFacetField, bleve.NewFacetRequest(categoryFacetField, maxCategoryFacetTerms))
+
+	result, err := im.index.Search(request)
+	if err != nil {
+		return nil, err
+	}
+
+	facet := result.Facets[categoryFacetField]
+	if facet == nil || facet.Terms == nil {
+		return nil, nil
+	}
+
+	counts := map[string]int{}
+	for _, term := range facet.Terms.Terms() {
+		counts[term.Term] += term.Count
+	}
+	return groupCategories(counts), nil
+}
+
+// groupCategories는 카테고리 전체 경로별 개수를 최상위/두 번째 단계 카테고리별 개수로 합칩니다
+func groupCategories(counts map[string]int) []CategoryFacet {
+	top := map[string]*CategoryFacet{}
+	second := map[string]map[string]int{}
+
+	for category, count := range counts {
+		parts := strings.Split(category, categorySeparator)
+		name := strings.TrimSpace(parts[0])
+		if name == "" {
+			continue
+		}
+
+		facet, ok := top[name]
+		if !ok {
+			facet = &CategoryFacet{Category: name}
+			top[name] = facet
+			second[name] = map[string]int{}
+		}
+		facet.Count += count
+
+		if len(parts) > 1 {
+			second[name][name+categorySeparator+strings.TrimSpace(parts[1])] += count
+		}
+	}
+
+	facets := make([]CategoryFacet, 0, len(top))
+	for name, facet := range top {
+		for sub, count := range second[name] {
+			facet.Subcategories = append(facet.Subcategories, SubcategoryFacet{Category: sub, Count: count})
+		}
+		sort.Slice(facet.Subcategories, func(i, j int) bool {
+			a, b := facet.Subcategories[i], facet.Subcategories[j]
+			return a.Count > b.Count || a.Count == b.Count && a.Category < b.Category
+		})
+		facets = append(facets, *facet)
+	}
+	sort.Slice(facets, func(i, j int) bool {
+		a, b := facets[i], facets[j]
+		return a.Count > b.Count || a.Count == b.Count && a.Category < b.Category
+	})
+	return facets
+}
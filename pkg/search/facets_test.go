@@ -0,0 +1,84 @@
+package search
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestGroupCategories(t *testing.T) {
+	got := groupCategories(map[string]int{
+		"Unity > 결제 > 인앱 결제": 2,
+		"Unity > 결제":         1,
+		"Unity > 광고":         1,
+		"Framework":          3,
+		"":                   5,
+	})
+
+	want := []CategoryFacet{
+		{Category: "Unity", Count: 4, Subcategories: []SubcategoryFacet{
+			{Category: "Unity > 결제", Count: 3},
+			{Category: "Unity > 광고", Count: 1},
+		}},
+		{Category: "Framework", Count: 3},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("groupCategories = %+v, want %+v", got, want)
+	}
+}
+
+func TestSearcher_SearchWithFacets(t *testing.T) {
+	s, tempDir := testSearcher(t, appsInTossIndexer, nil)
+	defer os.RemoveAll(tempDir)
+
+	if err := s.indexManager.CreateIndex(); err != nil {
+		t.Fatalf("Failed to create index: %v", err)
+	}
+	defer s.Close()
+
+	docs := []IndexDocument{
+		{ID: "unity-iap", Title: "Unity 인앱 결제", Content: "Unity에서 결제를 연동합니다.", Category: "Unity > 결제"},
+		{ID: "unity-refund", Title: "Unity 환불", Content: "결제를 환불합니다.", Category: "Unity > 결제"},
+		{ID: "unity-ads", Title: "Unity 광고", Content: "광고 보상 후 결제 혜택을 줍니다.", Category: "Unity > 광고"},
+		{ID: "payment", Title: "토스페이 결제", Content: "결제 연동 가이드입니다.", Category: "Payment > 토스페이"},
+		{ID: "navigation", Title: "Navigation", Content: "화면을 이동합니다.", Category: "Framework > 라우팅"},
+	}
+	if err := s.indexManager.IndexDocuments(docs); err != nil {
+		t.Fatalf("Failed to index: %v", err)
+	}
+
+	// limit보다 많은 문서가 매칭되어도 패싯은 매칭된 모든 문서를 셉니다
+	results, facets, err := s.SearchWithFacets(context.Background(), "결제", &SearchOptions{Limit: 1})
+	if err != nil {
+		t.Fatalf("SearchWithFacets failed: %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("Expected 1 result, got %d", len(results))
+	}
+
+	want := []CategoryFacet{
+		{Category: "Unity", Count: 3, Subcategories: []SubcategoryFacet{
+			{Category: "Unity > 결제", Count: 2},
+			{Category: "Unity > 광고", Count: 1},
+		}},
+		{Category: "Payment", Count: 1, Subcategories: []SubcategoryFacet{
+			{Category: "Payment > 토스페이", Count: 1},
+		}},
+	}
+	if !reflect.DeepEqual(facets, want) {
+		t.Errorf("Expected facets %+v, got %+v", want, facets)
+	}
+
+	// 완화한 검색으로 찾은 결과는 완화한 쿼리로 집계합니다
+	results, facets, err = s.SearchWithFacets(context.Background(), "Navigetion", nil)
+	if err != nil {
+		t.Fatalf("SearchWithFacets failed: %v", err)
+	}
+	if len(results) == 0 || results[0].Relaxation == "" {
+		t.Fatalf("Expected relaxed results, got %+v", results)
+	}
+	if len(facets) != 1 || facets[0].Category != "Framework" {
+		t.Errorf("Expected Framework facet for relaxed query, got %+v", facets)
+	}
+}
@@ -0,0 +1,327 @@
+package search
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/search/query"
+)
+
+// 결과가 없을 때 순서대로 시도하는 완화 방식입니다. SearchResult.Relaxation에 기록됩니다.
+const (
+	// RelaxDropTerms는 검색 문법에서 정보가 적은 단어를 하나씩 뺍니다
+	RelaxDropTerms = "drop_terms"
+	// RelaxFuzzyTitle은 제목을 포함한 모든 필드에 오타 허용을 넓혀 적용합니다
+	RelaxFuzzyTitle = "fuzzy_title"
+	// RelaxPrefix는 쿼리 단어의 앞부분(edge n-gram)만으로 매칭합니다
+	RelaxPrefix = "prefix"
+	// RelaxGlossary는 영어 단어를 문서에서 쓰는 한국어 용어로 바꿉니다
+	RelaxGlossary = "glossary"
+)
+
+const (
+	// 완화 검색의 오타 허용 거리입니다
+	relaxedFuzziness = 2
+
+	// 접두어 매칭에서 남기는 최소 길이 (룬 단위)
+	minRelaxedPrefixLength = 2
+)
+
+// searchRelaxed는 원래 쿼리로 결과가 없을 때 점점 느슨한 쿼리로 다시 검색합니다.
+// 결과를 낸 쿼리와 완화 방식의 이름을 함께 반환하며, 모두 실패하면 nil을 반환합니다.
+func (s *Searcher) searchRelaxed(text string, syntax bool, limit int, boosts FieldBoosts, explain bool) (*searchHits, error) {
+	plain := text
+	var excluded []queryClause
+	if syntax {
+		parsed, err := parseQuery(text)
+		if err != nil {
+			return nil, err
+		}
+
+		hits, err := s.dropTerms(parsed, limit, boosts, explain)
+		if err != nil || hits != nil {
+			return hits, err
+		}
+		plain = parsed.plainText()
+		excluded = parsed.excluded
+	}
+
+	relaxations := []struct {
+		name  string
+		build func(string, FieldBoosts) query.Query
+	}{
+		{RelaxFuzzyTitle, fuzzyAllFields},
+		{RelaxPrefix, s.prefixAllFields},
+		{RelaxGlossary, glossaryAllFields},
+	}
+	for _, r := range relaxations {
+		relaxed := r.build(plain, boosts)
+		if relaxed == nil {
+			continue
+		}
+		relaxed = excludeClauses(relaxed, excluded, boosts)
+		docs, scores, err := s.indexManager.searchRanked(relaxed, limit, boosts.Links, explain)
+		if err != nil {
+			return nil, err
+		}
+		if len(docs) > 0 {
+			return &searchHits{query: relaxed, docs: docs, scores: scores, relaxation: r.name}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// excludeClauses는 완화한 쿼리에도 -단어 제외 조건을 그대로 적용합니다.
+// 완화 쿼리는 제외 조건을 뺀 plainText로 만들므로, 감싸지 않으면 사용자가 뺀 문서가 근사 결과로 나올 수 있습니다.
+func excludeClauses(relaxed query.Query, excluded []queryClause, boosts FieldBoosts) query.Query {
+	if len(excluded) == 0 {
+		return relaxed
+	}
+	boolQuery := bleve.NewBooleanQuery()
+	boolQuery.AddMust(relaxed)
+	for _, clause := range excluded {
+		boolQuery.AddMustNot(clause.toBleveQuery(boosts))
+	}
+	return boolQuery
+}
+
+// dropTerms는 결과가 나올 때까지 AND로 묶인 조건을 하나씩 뺍니다.
+// 인덱스에 아예 없는 조건을 먼저 빼고, 그다음 가장 많은 문서와 매칭되는(정보가 가장 적은) 조건을 뺍니다.
+func (s *Searcher) dropTerms(parsed *parsedQuery, limit int, boosts FieldBoosts, explain bool) (*searchHits, error) {
+	current := &parsedQuery{groups: parsed.groups, excluded: parsed.excluded}
+
+	for len(current.groups) > 1 {
+		counts := make([]uint64, len(current.groups))
+		for i, group := range current.groups {
+			count, err := s.indexManager.countQuery((&parsedQuery{groups: [][]queryClause{group}}).toBleveQuery(boosts))
+			if err != nil {
+				return nil, err
+			}
+			counts[i] = count
+		}
+
+		drop := -1
+		for i, count := range counts {
+			if count == 0 {
+				drop = i
+				break
+			}
+		}
+		if drop < 0 {
+			drop = 0
+			for i, count := range counts {
+				if count > counts[drop] {
+					drop = i
+				}
+			}
+		}
+
+		groups := make([][]queryClause, 0, len(current.groups)-1)
+		groups = append(groups, current.groups[:drop]...)
+		groups = append(groups, current.groups[drop+1:]...)
+		current = &parsedQuery{groups: groups, excluded: current.excluded}
+
+		relaxed := current.toBleveQuery(boosts)
+		docs, scores, err := s.indexManager.searchRanked(relaxed, limit, boosts.Links, explain)
+		if err != nil {
+			return nil, err
+		}
+		if len(docs) > 0 {
+			return &searchHits{query: relaxed, docs: docs, scores: scores, relaxation: RelaxDropTerms}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// plainText는 제외 조건을 뺀 모든 단어와 구문을 공백으로 이어 붙입니다
+func (p *parsedQuery) plainText() string {
+	var words []string
+	for _, group := range p.groups {
+		for _, clause := range group {
+			words = append(words, clause.text)
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// countQuery는 쿼리와 매칭되는 문서 수를 반환합니다
+func (im *IndexManager) countQuery(countQuery query.Query) (uint64, error) {
+	result, err := im.index.Search(bleve.NewSearchRequestOptions(countQuery, 0, 0, false))
+	if err != nil {
+		return 0, err
+	}
+	return result.Total, nil
+}
+
+// fuzzyAllFields는 matchAllFields와 같지만 제목과 카테고리에도 오타를 허용하고 허용 거리를 넓힙니다
+func fuzzyAllFields(text string, boosts FieldBoosts) query.Query {
+	fields := []struct {
+		name  string
+		boost float64
+	}{
+		{"title", boosts.Title},
+		{"description", boosts.Description},
+		{"content", boosts.Content},
+		{"category", boosts.Category},
+	}
+
+	queries := make([]query.Query, len(fields))
+	for i, f := range fields {
+		matchQuery := bleve.NewMatchQuery(text)
+		matchQuery.SetField(f.name)
+		matchQuery.Analyzer = "cjk_search"
+		matchQuery.SetFuzziness(relaxedFuzziness)
+		matchQuery.SetBoost(f.boost)
+		queries[i] = matchQuery
+	}
+	return bleve.NewDisjunctionQuery(queries...)
+}
+
+// prefixAllFields는 쿼리 단어마다 앞부분을 줄여 가며 인덱스의 edge n-gram과 매칭합니다.
+// 예를 들어 "buttons"는 "button", "butto", ...(2/3 길이까지)와 매칭되며, 긴 접두어일수록 점수가 높습니다.
+func (s *Searcher) prefixAllFields(text string, boosts FieldBoosts) query.Query {
+	analyzer := s.indexManager.index.Mapping().AnalyzerNamed("cjk_search")
+	// 제목과 본문 부스트가 모두 0이면 가중치가 전부 0인 쿼리가 되어 점수가 NaN이 됩니다
+	if analyzer == nil || boosts.Title+boosts.Content == 0 {
+		return nil
+	}
+
+	seen := map[string]bool{}
+	var queries []query.Query
+	for _, token := range analyzer.Analyze([]byte(text)) {
+		runes := []rune(indexedTerm(string(token.Term)))
+		minLength := max(minRelaxedPrefixLength, (len(runes)*2+2)/3)
+		for n := len(runes); n >= minLength; n-- {
+			prefix := string(runes[:n])
+			if seen[prefix] {
+				continue
+			}
+			seen[prefix] = true
+
+			weight := float64(n) / float64(len(runes))
+			queries = append(queries,
+				termMatch("title", prefix, boosts.Title*weight),
+				termMatch("content", prefix, boosts.Content*weight),
+			)
+		}
+	}
+	if len(queries) == 0 {
+		return nil
+	}
+	return bleve.NewDisjunctionQuery(queries...)
+}
+
+// glossaryAllFields는 쿼리의 영어 단어를 용어집의 한국어 용어로 바꿔 검색합니다.
+// 바꿀 단어가 없으면 nil을 반환합니다.
+func glossaryAllFields(text string, boosts FieldBoosts) query.Query {
+	translated, ok := translateGlossary(text)
+	if !ok {
+		return nil
+	}
+	return matchAllFields(translated, boosts)
+}
+
+// glossary는 영어로 검색했을 때 문서에서 쓰는 한국어 용어입니다.
+// 여러 단어로 된 항목이 먼저 적용되도록 translateGlossary에서 길이순으로 정렬합니다.
+var glossary = map[string]string{
+	"payment":         "결제",
+	"payments":        "결제",
+	"pay":             "결제",
+	"checkout":        "결제",
+	"refund":          "환불",
+	"refunds":         "환불",
+	"in-app purchase": "인앱 결제",
+	"iap":             "인앱 결제",
+	"subscription":    "구독",
+	"login":           "로그인",
+	"log in":          "로그인",
+	"sign in":         "로그인",
+	"auth":            "인증",
+	"authentication":  "인증",
+	"user":            "사용자",
+	"ad":              "광고",
+	"ads":             "광고",
+	"advertisement":   "광고",
+	"reward":          "보상",
+	"share":           "공유",
+	"sharing":         "공유",
+	"notification":    "알림",
+	"notifications":   "알림",
+	"push":            "푸시",
+	"permission":      "권한",
+	"permissions":     "권한",
+	"camera":          "카메라",
+	"location":        "위치",
+	"storage":         "저장소",
+	"navigation":      "내비게이션",
+	"screen":          "화면",
+	"scroll":          "스크롤",
+	"deploy":          "배포",
+	"deployment":      "배포",
+	"release":         "출시",
+	"review":          "검수",
+	"sandbox":         "샌드박스",
+	"test":            "테스트",
+	"testing":         "테스트",
+	"error":           "에러",
+	"errors":          "에러",
+	"game":            "게임",
+	"leaderboard":     "리더보드",
+	"promotion":       "프로모션",
+	"analytics":       "분석",
+	"settlement":      "정산",
+	"policy":          "정책",
+	"guide":           "가이드",
+	"setup":           "설정",
+	"settings":        "설정",
+	"install":         "설치",
+	"installation":    "설치",
+	"getting started": "시작하기",
+	"tutorial":        "튜토리얼",
+	"design":          "디자인",
+	"color":           "색상",
+	"typography":      "타이포그래피",
+}
+
+// translateGlossary는 text에 있는 용어집 단어를 한국어로 바꿉니다. 하나라도 바꿨으면 true입니다.
+func translateGlossary(text string) (string, bool) {
+	terms := make([]string, 0, len(glossary))
+	for term := range glossary {
+		terms = append(terms, term)
+	}
+	sort.Slice(terms, func(i, j int) bool {
+		if len(terms[i]) != len(terms[j]) {
+			return len(terms[i]) > len(terms[j])
+		}
+		return terms[i] < terms[j]
+	})
+
+	words := strings.Fields(strings.ToLower(text))
+	var translated []string
+	changed := false
+	for i := 0; i < len(words); {
+		matched := false
+		for _, term := range terms {
+			parts := strings.Fields(term)
+			if i+len(parts) > len(words) || strings.Join(words[i:i+len(parts)], " ") != term {
+				continue
+			}
+			translated = append(translated, glossary[term])
+			i += len(parts)
+			matched, changed = true, true
+			break
+		}
+		if !matched {
+			translated = append(translated, words[i])
+			i++
+		}
+	}
+
+	if !changed {
+		return text, false
+	}
+	return strings.Join(translated, " "), true
+}
@@ -0,0 +1,128 @@
+package search
+
+import (
+	"context"
+	"os"
+	"testing"
+)
+
+func TestTranslateGlossary(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		changed bool
+	}{
+		{"payment refund", "결제 환불", true},
+		{"How to Sign In", "how to 로그인", true},
+		{"in-app purchase test", "인앱 결제 테스트", true},
+		{"결제 연동", "결제 연동", false},
+		{"Button", "Button", false},
+	}
+
+	for _, tt := range tests {
+		got, changed := translateGlossary(tt.input)
+		if got != tt.want || changed != tt.changed {
+			t.Errorf("translateGlossary(%q) = (%q, %v), want (%q, %v)", tt.input, got, changed, tt.want, tt.changed)
+		}
+	}
+}
+
+func TestSearcher_SearchFallback(t *testing.T) {
+	s, tempDir := testSearcher(t, appsInTossIndexer, nil)
+	defer os.RemoveAll(tempDir)
+
+	if err := s.indexManager.CreateIndex(); err != nil {
+		t.Fatalf("Failed to create index: %v", err)
+	}
+	defer s.Close()
+
+	docs := []IndexDocument{
+		{ID: "payment", Title: "결제 연동", Content: "토스페이 결제를 연동합니다."},
+		{ID: "navigation", Title: "Navigation", Content: "useNavigation으로 화면을 이동합니다."},
+		{ID: "scrollview", Title: "IOScrollView", Content: "스크롤 영역을 만듭니다."},
+	}
+	if err := s.indexManager.IndexDocuments(docs); err != nil {
+		t.Fatalf("Failed to index: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		query      string
+		syntax     bool
+		wantFirst  string
+		relaxation string
+	}{
+		{"exact match is not relaxed", "결제", false, "payment", ""},
+		{"missing AND term is dropped", "결제 +블록체인", true, "payment", RelaxDropTerms},
+		{"title typo", "Navigetion", false, "navigation", RelaxFuzzyTitle},
+		{"longer word matches indexed prefix", "IOScrollViews", false, "scrollview", RelaxPrefix},
+		{"english term translated", "payment", false, "payment", RelaxGlossary},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := s.Search(context.Background(), tt.query, &SearchOptions{Syntax: tt.syntax})
+			if err != nil {
+				t.Fatalf("Search failed: %v", err)
+			}
+			if len(results) == 0 {
+				t.Fatalf("Expected results for %q", tt.query)
+			}
+			if results[0].ID != tt.wantFirst {
+				t.Errorf("Expected %s first, got %+v", tt.wantFirst, results)
+			}
+			for _, r := range results {
+				if r.Relaxation != tt.relaxation {
+					t.Errorf("Expected relaxation %q, got %q", tt.relaxation, r.Relaxation)
+				}
+			}
+		})
+	}
+
+	// 완화를 끄면 결과가 없어야 함
+	results, err := s.Search(context.Background(), "payment", &SearchOptions{DisableFallback: true})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(results) != 0 {
+		t.Errorf("Expected no results with fallback disabled, got %+v", results)
+	}
+}
+
+func TestSearcher_SearchFallbackKeepsExclusions(t *testing.T) {
+	s, tempDir := testSearcher(t, appsInTossIndexer, nil)
+	defer os.RemoveAll(tempDir)
+
+	if err := s.indexManager.CreateIndex(); err != nil {
+		t.Fatalf("Failed to create index: %v", err)
+	}
+	defer s.Close()
+
+	docs := []IndexDocument{
+		{ID: "navigation", Title: "Navigation", Content: "useNavigation으로 화면을 이동합니다."},
+		{ID: "navigation-legacy", Title: "Navigation 레거시", Content: "이전 방식의 화면 이동입니다."},
+		{ID: "scrollview", Title: "IOScrollView", Content: "스크롤 영역을 만듭니다."},
+		{ID: "scrollview-legacy", Title: "IOScrollView 레거시", Content: "이전 스크롤 영역입니다."},
+	}
+	if err := s.indexManager.IndexDocuments(docs); err != nil {
+		t.Fatalf("Failed to index: %v", err)
+	}
+
+	tests := []struct {
+		query      string
+		want       string
+		relaxation string
+	}{
+		{"Navigetion -레거시", "navigation", RelaxFuzzyTitle},
+		{"IOScrollViews -레거시", "scrollview", RelaxPrefix},
+	}
+	for _, tt := range tests {
+		results, err := s.Search(context.Background(), tt.query, &SearchOptions{Syntax: true})
+		if err != nil {
+			t.Fatalf("Search(%q) failed: %v", tt.query, err)
+		}
+		if len(results) != 1 || results[0].ID != tt.want || results[0].Relaxation != tt.relaxation {
+			t.Errorf("Search(%q): expected only %s via %s, got %+v", tt.query, tt.want, tt.relaxation, results)
+		}
+	}
+}
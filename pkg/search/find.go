@@ -0,0 +1,148 @@
+package search
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	// DefaultFindContext는 일치한 줄 앞뒤로 함께 반환하는 줄 수입니다
+	DefaultFindContext = 2
+	// DefaultFindMaxMatches는 반환하는 일치 줄의 최대 개수입니다
+	DefaultFindMaxMatches = 20
+)
+
+// ErrInvalidLineRange는 LineRange의 줄 범위가 문서를 벗어났거나 거꾸로 되어 있음을 나타냅니다
+var ErrInvalidLineRange = errors.New("invalid line range")
+
+// FindOptions는 FindInContent의 검색 방식입니다
+type FindOptions struct {
+	// Regex가 true이면 pattern을 정규식(RE2)으로, 아니면 대소문자를 구분하지 않는 문자열로 찾습니다
+	Regex bool
+	// Context는 일치한 줄 앞뒤로 포함할 줄 수입니다. 음수이면 DefaultFindContext를 사용합니다.
+	Context int
+	// MaxMatches는 일치 줄의 최대 개수입니다. 0 이하이면 DefaultFindMaxMatches를 사용합니다.
+	MaxMatches int
+}
+
+// FindHunk는 일치한 줄과 앞뒤 문맥을 묶은 본문의 한 부분입니다. 문맥이 겹치는 일치는 하나로 합칩니다.
+type FindHunk struct {
+	// StartLine과 EndLine은 Text의 첫 줄과 마지막 줄 번호입니다 (1부터, 끝 포함)
+	StartLine int `json:"start_line" jsonschema:"First line of text (1-based)"`
+	EndLine   int `json:"end_line" jsonschema:"Last line of text (inclusive)"`
+	// MatchLines는 Text 안에서 pattern과 일치한 줄 번호입니다
+	MatchLines []int  `json:"match_lines" jsonschema:"Line numbers that matched the pattern"`
+	Text       string `json:"text" jsonschema:"The lines from start_line to end_line"`
+}
+
+// FindResult는 FindInContent의 결과입니다
+type FindResult struct {
+	Hunks []FindHunk
+	// TotalMatches는 MaxMatches로 자르기 전 일치한 줄 수입니다
+	TotalMatches int
+	// TotalLines는 문서 전체의 줄 수입니다
+	TotalLines int
+}
+
+// FindInContent는 content에서 pattern과 일치하는 줄을 찾아 앞뒤 문맥과 줄 번호를 함께 반환합니다
+func FindInContent(content, pattern string, opts FindOptions) (FindResult, error) {
+	if strings.TrimSpace(pattern) == "" {
+		return FindResult{}, InvalidArgument(errors.New("pattern must not be empty"))
+	}
+
+	match, err := lineMatcher(pattern, opts.Regex)
+	if err != nil {
+		return FindResult{}, err
+	}
+	context := opts.Context
+	if context < 0 {
+		context = DefaultFindContext
+	}
+	maxMatches := opts.MaxMatches
+	if maxMatches <= 0 {
+		maxMatches = DefaultFindMaxMatches
+	}
+
+	lines := splitLines(content)
+	result := FindResult{TotalLines: len(lines)}
+	for i, line := range lines {
+		if !match(line) {
+			continue
+		}
+		result.TotalMatches++
+		if result.TotalMatches > maxMatches {
+			continue
+		}
+
+		number := i + 1
+		start, end := max(number-context, 1), min(number+context, len(lines))
+		if n := len(result.Hunks); n > 0 && start <= result.Hunks[n-1].EndLine+1 {
+			last := &result.Hunks[n-1]
+			last.EndLine = max(last.EndLine, end)
+			last.MatchLines = append(last.MatchLines, number)
+			continue
+		}
+		result.Hunks = append(result.Hunks, FindHunk{StartLine: start, EndLine: end, MatchLines: []int{number}})
+	}
+
+	for i := range result.Hunks {
+		hunk := &result.Hunks[i]
+		hunk.Text = strings.Join(lines[hunk.StartLine-1:hunk.EndLine], "\n")
+	}
+	return result, nil
+}
+
+// lineMatcher는 pattern으로 한 줄이 일치하는지 확인하는 함수를 만듭니다
+func lineMatcher(pattern string, regex bool) (func(string) bool, error) {
+	if regex {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, InvalidArgument(fmt.Errorf("invalid regex %q: %w", pattern, err))
+		}
+		return re.MatchString, nil
+	}
+
+	needle := strings.ToLower(pattern)
+	return func(line string) bool {
+		return strings.Contains(strings.ToLower(line), needle)
+	}, nil
+}
+
+// LineRange는 content의 startLine부터 endLine까지(1부터, 끝 포함)의 줄을 Page로 반환합니다.
+// 0이면 각각 문서의 처음과 끝을 뜻하고, endLine이 문서보다 길면 마지막 줄까지 반환합니다.
+// 함께 반환하는 값은 문서 전체의 줄 수입니다.
+func LineRange(content string, startLine, endLine int) (Page, int, error) {
+	lines := splitLines(content)
+	if startLine <= 0 {
+		startLine = 1
+	}
+	if endLine <= 0 || endLine > len(lines) {
+		endLine = len(lines)
+	}
+	if startLine > len(lines) || startLine > endLine {
+		return Page{}, len(lines), fmt.Errorf("%w: %d-%d (document has %d lines)", ErrInvalidLineRange, startLine, endLine, len(lines))
+	}
+
+	offset := 0
+	for _, line := range lines[:startLine-1] {
+		offset += len([]rune(line)) + 1
+	}
+	text := strings.Join(lines[startLine-1:endLine], "\n")
+	page := Page{
+		Content:         text,
+		Offset:          offset,
+		End:             offset + len([]rune(text)),
+		HasMore:         endLine < len(lines),
+		EstimatedTokens: EstimateTokens(text),
+		TotalChars:      len([]rune(content)),
+		TotalTokens:     EstimateTokens(content),
+	}
+	return page, len(lines), nil
+}
+
+// splitLines는 content를 줄로 나눕니다. 마지막 줄바꿈 뒤의 빈 줄은 세지 않습니다.
+func splitLines(content string) []string {
+	return strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+}
@@ -0,0 +1,76 @@
+package search
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestFindInContent_MergesOverlappingContext(t *testing.T) {
+	result, err := FindInContent(pagedDoc, "invalid", FindOptions{Context: 1})
+	if err != nil {
+		t.Fatalf("FindInContent failed: %v", err)
+	}
+	if result.TotalMatches != 1 || len(result.Hunks) != 1 {
+		t.Fatalf("Expected one case-insensitive match, got %+v", result)
+	}
+	hunk := result.Hunks[0]
+	if hunk.MatchLines[0] != 19 || hunk.StartLine != 18 || hunk.EndLine != 19 {
+		t.Errorf("Expected line 19 with one line of context clipped at the end, got %+v", hunk)
+	}
+	if hunk.Text != "| 코드 | 설명 |\n| INVALID_PARAMETER | 잘못된 파라미터 |" {
+		t.Errorf("Unexpected hunk text %q", hunk.Text)
+	}
+
+	result, err = FindInContent(pagedDoc, `^#+ `, FindOptions{Regex: true, Context: 0})
+	if err != nil {
+		t.Fatalf("FindInContent failed: %v", err)
+	}
+	var matches []int
+	for _, hunk := range result.Hunks {
+		matches = append(matches, hunk.MatchLines...)
+	}
+	// 코드 블록 안의 "# 설치 확인"도 줄 단위 검색에서는 일치합니다
+	if !reflect.DeepEqual(matches, []int{1, 5, 12, 16}) {
+		t.Errorf("Expected heading lines, got %v", matches)
+	}
+
+	result, _ = FindInContent(pagedDoc, "설치", FindOptions{Context: 1, MaxMatches: 2})
+	if result.TotalMatches != 3 || len(result.Hunks) != 1 || !reflect.DeepEqual(result.Hunks[0].MatchLines, []int{5, 7}) {
+		t.Errorf("Expected two adjacent matches merged out of three, got %+v", result)
+	}
+}
+
+func TestFindInContent_InvalidPattern(t *testing.T) {
+	if _, err := FindInContent(pagedDoc, "(", FindOptions{Regex: true}); err == nil {
+		t.Error("Expected an error for an invalid regex")
+	}
+	if _, err := FindInContent(pagedDoc, " ", FindOptions{}); err == nil {
+		t.Error("Expected an error for an empty pattern")
+	}
+}
+
+func TestLineRange(t *testing.T) {
+	page, total, err := LineRange(pagedDoc, 16, 0)
+	if err != nil {
+		t.Fatalf("LineRange failed: %v", err)
+	}
+	if total != 19 || page.Content != "## 에러 코드\n\n| 코드 | 설명 |\n| INVALID_PARAMETER | 잘못된 파라미터 |" || page.HasMore {
+		t.Errorf("Unexpected range %+v of %d lines", page, total)
+	}
+	if got := string([]rune(pagedDoc)[page.Offset:page.End]); got != page.Content {
+		t.Errorf("Expected offsets to locate the range, got %q", got)
+	}
+
+	page, _, _ = LineRange(pagedDoc, 5, 7)
+	if page.Content != "## 설치\n\n패키지를 설치합니다." || !page.HasMore {
+		t.Errorf("Unexpected range %+v", page)
+	}
+
+	if _, _, err := LineRange(pagedDoc, 5, 3); !errors.Is(err, ErrInvalidLineRange) {
+		t.Errorf("Expected ErrInvalidLineRange, got %v", err)
+	}
+	if _, _, err := LineRange(pagedDoc, 20, 0); !errors.Is(err, ErrInvalidLineRange) {
+		t.Errorf("Expected ErrInvalidLineRange past the end, got %v", err)
+	}
+}
@@ -0,0 +1,83 @@
+package search
+
+import (
+	"time"
+)
+
+const (
+	// DefaultFreshnessTTL은 원본 변경 여부를 확인한 뒤 다시 확인하지 않는 기본 시간입니다
+	DefaultFreshnessTTL = 6 * time.Hour
+
+	// offlineRetryInterval은 원본 확인이 네트워크 오류로 실패한 뒤 다시 시도하지 않는 시간입니다.
+	// 오프라인 환경에서 매 호출마다 재시도와 타임아웃을 기다리지 않도록 합니다.
+	offlineRetryInterval = 5 * time.Minute
+)
+
+// RefreshPolicy는 EnsureIndex가 원본 변경 여부를 확인하는 방식입니다
+type RefreshPolicy struct {
+	// Force가 true이면 TTL과 최근 실패 기록을 무시하고 원본을 확인합니다
+	Force bool
+	// FreshnessTTL이 0보다 크면 캐시 메타데이터에 저장되어 이후 호출에도 적용됩니다
+	FreshnessTTL time.Duration
+}
+
+// freshnessTTL은 메타데이터에 저장된 TTL을 반환합니다
+func (m CacheMetadata) freshnessTTL() time.Duration {
+	if m.FreshnessTTLSeconds > 0 {
+		return time.Duration(m.FreshnessTTLSeconds) * time.Second
+	}
+	return DefaultFreshnessTTL
+}
+
+// NeedsValidation은 원본 변경 여부를 네트워크로 다시 확인해야 하는지 반환합니다.
+// 마지막 확인 후 TTL이 지나지 않았거나, 최근 확인이 네트워크 오류로 실패했다면 false입니다.
+func (cm *CacheManager) NeedsValidation(now time.Time) bool {
+	metadata, err := cm.LoadMetadata()
+	if err != nil {
+		return true
+	}
+
+	if failedAt, ok := parseMetadataTime(metadata.LastCheckFailed); ok && now.Sub(failedAt) < offlineRetryInterval {
+		return false
+	}
+
+	validatedAt, ok := parseMetadataTime(metadata.LastValidated)
+	if !ok {
+		return true
+	}
+
+	return now.Sub(validatedAt) >= metadata.freshnessTTL()
+}
+
+// MarkValidated는 원본 확인에 성공했음을 기록합니다
+func (cm *CacheManager) MarkValidated() error {
+	return cm.updateMetadata(func(metadata *CacheMetadata) {
+		metadata.LastValidated = time.Now().UTC().Format(time.RFC3339)
+		metadata.LastCheckFailed = ""
+	})
+}
+
+// MarkCheckFailed는 원본 확인이 네트워크 오류로 실패했음을 기록합니다
+func (cm *CacheManager) MarkCheckFailed() error {
+	return cm.updateMetadata(func(metadata *CacheMetadata) {
+		metadata.LastCheckFailed = time.Now().UTC().Format(time.RFC3339)
+	})
+}
+
+// SetFreshnessTTL은 원본 확인 간격을 메타데이터에 저장합니다
+func (cm *CacheManager) SetFreshnessTTL(ttl time.Duration) error {
+	return cm.updateMetadata(func(metadata *CacheMetadata) {
+		metadata.FreshnessTTLSeconds = int(ttl / time.Second)
+	})
+}
+
+func parseMetadataTime(value string) (time.Time, bool) {
+	if value == "" {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
@@ -0,0 +1,92 @@
+package search
+
+import "unicode"
+
+// 한글 음절(가-힣)을 초성/중성/종성으로 나누는 데 쓰는 상수입니다
+const (
+	hangulSyllableBase = 0xAC00
+	hangulSyllableLast = 0xD7A3
+	hangulJungCount    = 21
+	hangulJongCount    = 28
+)
+
+// isHangulSyllable은 r이 완성형 한글 음절인지 확인합니다
+func isHangulSyllable(r rune) bool {
+	return r >= hangulSyllableBase && r <= hangulSyllableLast
+}
+
+// isHangulWord는 s가 완성형 한글 음절로만 이루어졌는지 확인합니다
+func isHangulWord(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if !isHangulSyllable(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// decomposeHangul은 한글 음절을 초성, 중성, 종성(없으면 생략) 자모 인덱스로 나눕니다.
+// 서로 다른 위치의 자모가 같은 값으로 비교되지 않도록 위치마다 값의 범위를 나눕니다.
+// 한글 음절이 아닌 문자는 그대로 하나의 단위로 반환합니다.
+func decomposeHangul(s string) []rune {
+	var jamo []rune
+	for _, r := range s {
+		if !isHangulSyllable(r) {
+			jamo = append(jamo, unicode.ToLower(r))
+			continue
+		}
+		offset := r - hangulSyllableBase
+		cho := offset / (hangulJungCount * hangulJongCount)
+		jung := (offset % (hangulJungCount * hangulJongCount)) / hangulJongCount
+		jong := offset % hangulJongCount
+
+		jamo = append(jamo, 0x1100+cho, 0x1161+jung)
+		if jong > 0 {
+			jamo = append(jamo, 0x11A7+jong)
+		}
+	}
+	return jamo
+}
+
+// jamoDistance는 두 문자열을 자모 단위로 나눈 뒤의 편집 거리입니다.
+// "겔제"와 "결제"처럼 모음 하나만 다른 오타는 음절 단위로는 1이지만 자모 단위로도 1이고,
+// "결제"와 "견제"처럼 받침만 추가된 경우도 1로 계산됩니다.
+func jamoDistance(a, b string) int {
+	return editDistance(decomposeHangul(a), decomposeHangul(b))
+}
+
+// editDistance는 인접한 두 문자의 자리바꿈을 한 번의 편집으로 세는 편집 거리(OSA)입니다
+func editDistance(a, b []rune) int {
+	if len(a) == 0 {
+		return len(b)
+	}
+	if len(b) == 0 {
+		return len(a)
+	}
+
+	prev2 := make([]int, len(b)+1)
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				curr[j] = min(curr[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, curr = prev, curr, prev2
+	}
+	return prev[len(b)]
+}
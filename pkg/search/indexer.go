@@ -4,6 +4,11 @@ import (
 	"fmt"
 	"math"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/blevesearch/bleve/v2"
 	"github.com/blevesearch/bleve/v2/analysis/analyzer/custom"
@@ -12,6 +17,9 @@ import (
 	"github.com/blevesearch/bleve/v2/analysis/token/lowercase"
 	"github.com/blevesearch/bleve/v2/analysis/tokenizer/unicode"
 	"github.com/blevesearch/bleve/v2/mapping"
+	blevesearch "github.com/blevesearch/bleve/v2/search"
+	"github.com/blevesearch/bleve/v2/search/query"
+	"github.com/toss/apps-in-toss-ax/internal/filelock"
 	"github.com/toss/apps-in-toss-ax/pkg/docid"
 	"github.com/toss/apps-in-toss-ax/pkg/llms"
 )
@@ -23,6 +31,31 @@ const (
 	llmsFullUrl = "https://developers-apps-in-toss.toss.im/llms-full.txt"
 )
 
+// IndexSchemaVersion은 createIndexMapping이 만드는 매핑의 버전입니다.
+// analyzer나 필드 매핑을 바꾸면 반드시 올려야 하며, 버전이 다른 캐시는 EnsureIndex가 다시 만듭니다.
+const IndexSchemaVersion = 3
+
+// schemaVersionKey는 인덱스 내부 저장소에 스키마 버전을 기록하는 키입니다
+var schemaVersionKey = []byte("ax_schema_version")
+
+const (
+	// 인덱스 교체를 직렬화하는 잠금 파일과 빌드 중 사용하는 임시 디렉터리의 접미사입니다
+	indexLockSuffix    = ".lock"
+	indexStagingSuffix = ".staging-"
+	indexRetiredSuffix = ".old-"
+
+	// 인덱스를 연 프로세스가 닫을 때까지 공유 잠금을 잡는 파일입니다. 인덱스 디렉터리 안에 있으므로
+	// 교체로 옮겨진 디렉터리에도 따라가며, 배타적 잠금을 얻을 수 있으면 아무도 그 디렉터리를 쓰지 않는 것입니다.
+	indexInUseFile = "ax-in-use.lock"
+
+	// 비정상 종료로 남은 임시 디렉터리를 정리하기 전까지 기다리는 시간입니다.
+	// 다른 프로세스가 아직 빌드 중인 디렉터리를 지우지 않도록 넉넉하게 잡습니다.
+	staleStagingAge = time.Hour
+
+	// 다른 프로세스가 같은 인덱스를 쓰기 모드로 열고 있을 때 무한정 대기하지 않도록 합니다
+	indexOpenTimeout = "10s"
+)
+
 // appsInTossIndexer는 AppsInToss llms-full.txt 내용을 IndexDocument로 변환합니다
 func appsInTossIndexer(content string, categoryMap map[string]string) []IndexDocument {
 	parsedDocs := ParseLlmsFull(content)
@@ -53,11 +86,23 @@ type IndexDocument struct {
 	Description string `json:"description"`
 	URL         string `json:"url"`
 	Category    string `json:"category"`
+
+	// 색인할 때 withLinks가 채우는 링크 정보입니다
+	LinkKey string   `json:"link_key,omitempty"` // 정규화된 자기 URL
+	Links   []string `json:"links,omitempty"`    // 본문 링크의 정규화된 URL
+
+	// explanation은 Explain을 켜고 검색했을 때 bleve가 돌려준 점수 설명입니다 (색인하지 않음)
+	explanation *blevesearch.Explanation
 }
 
 type IndexManager struct {
 	indexPath string
 	index     bleve.Index
+	// inUse는 OpenIndex로 연 인덱스 디렉터리의 사용 중 잠금입니다
+	inUse *filelock.Lock
+
+	suggestMu    sync.Mutex
+	suggestCache *suggesterCache
 }
 
 func NewIndexManager(indexPath string) *IndexManager {
@@ -115,11 +160,21 @@ func (im *IndexManager) createIndexMapping() (mapping.IndexMapping, error) {
 	docMapping.AddFieldMappingsAt("title", textFieldMapping)
 	docMapping.AddFieldMappingsAt("content", textFieldMapping)
 	docMapping.AddFieldMappingsAt("description", textFieldMapping)
-	docMapping.AddFieldMappingsAt("category", textFieldMapping)
+
+	// 카테고리는 검색용 텍스트 필드와 함께, 패싯 집계를 위해 전체 경로를 하나의 단어로 색인한 하위 필드를 둡니다
+	categoryKeywordMapping := bleve.NewTextFieldMapping()
+	categoryKeywordMapping.Name = categoryFacetField
+	categoryKeywordMapping.Analyzer = "keyword"
+	categoryKeywordMapping.Store = false
+	categoryKeywordMapping.IncludeInAll = false
+	categoryKeywordMapping.IncludeTermVectors = false
+	docMapping.AddFieldMappingsAt("category", textFieldMapping, categoryKeywordMapping)
 
 	keywordMapping := bleve.NewTextFieldMapping()
 	keywordMapping.Analyzer = "keyword"
 	docMapping.AddFieldMappingsAt("url", keywordMapping)
+	docMapping.AddFieldMappingsAt("link_key", keywordMapping)
+	docMapping.AddFieldMappingsAt("links", keywordMapping)
 
 	indexMapping.AddDocumentMapping("document", docMapping)
 	indexMapping.DefaultMapping = docMapping
@@ -127,12 +182,21 @@ func (im *IndexManager) createIndexMapping() (mapping.IndexMapping, error) {
 	return indexMapping, nil
 }
 
+// CreateIndex는 indexPath에 쓰기 가능한 빈 인덱스를 직접 생성합니다.
+// 기존 인덱스는 잠금을 잡은 상태에서 삭제되지만, 이미 열려 있는 인덱스를 교체하지는 못하므로
+// 여러 프로세스가 공유하는 캐시에는 BuildIndex를 사용해야 합니다.
 func (im *IndexManager) CreateIndex() error {
 	indexMapping, err := im.createIndexMapping()
 	if err != nil {
 		return err
 	}
 
+	lock, err := filelock.Exclusive(im.lockPath())
+	if err != nil {
+		return err
+	}
+	defer lock.Unlock()
+
 	// 기존 경로가 남아있으면 삭제 후 재생성
 	if _, statErr := os.Stat(im.indexPath); statErr == nil {
 		_ = os.RemoveAll(im.indexPath)
@@ -142,25 +206,220 @@ func (im *IndexManager) CreateIndex() error {
 	if err != nil {
 		return err
 	}
+	if err := setSchemaVersion(index); err != nil {
+		index.Close()
+		return err
+	}
 
 	im.index = index
 	return nil
 }
 
+// BuildIndex는 documents로 새 인덱스를 만들어 indexPath에 원자적으로 교체한 뒤 읽기 전용으로 엽니다.
+// 인덱스는 같은 디렉터리의 임시 경로에서 만들어지므로 빌드 중에도 다른 프로세스는 기존 인덱스를
+// 계속 사용할 수 있고, 여러 프로세스가 동시에 빌드해도 마지막으로 교체한 인덱스가 온전히 남습니다.
+func (im *IndexManager) BuildIndex(documents []IndexDocument) error {
+	return im.buildIndex(documents, nil)
+}
+
+// buildIndex는 BuildIndex와 같으며, onBatch가 nil이 아니면 indexBatchSize개를 색인할 때마다 지금까지 색인한 문서 수로 호출합니다
+func (im *IndexManager) buildIndex(documents []IndexDocument, onBatch func(indexed int)) error {
+	indexMapping, err := im.createIndexMapping()
+	if err != nil {
+		return err
+	}
+
+	parentDir := filepath.Dir(im.indexPath)
+	if err := os.MkdirAll(parentDir, 0755); err != nil {
+		return err
+	}
+
+	stagingPath, err := os.MkdirTemp(parentDir, filepath.Base(im.indexPath)+indexStagingSuffix+"*")
+	if err != nil {
+		return err
+	}
+
+	if err := writeIndex(stagingPath, indexMapping, documents, onBatch); err != nil {
+		_ = os.RemoveAll(stagingPath)
+		return err
+	}
+
+	// Windows에서는 열린 파일이 있는 디렉터리를 옮길 수 없으므로 교체 전에 닫아둡니다
+	if err := im.Close(); err != nil {
+		_ = os.RemoveAll(stagingPath)
+		return err
+	}
+
+	if err := im.publish(stagingPath); err != nil {
+		_ = os.RemoveAll(stagingPath)
+		_ = im.OpenIndex()
+		return err
+	}
+
+	return im.OpenIndex()
+}
+
+// writeIndex는 path에 인덱스를 새로 만들어 documents를 indexBatchSize개씩 색인한 뒤 닫습니다
+func writeIndex(path string, indexMapping mapping.IndexMapping, documents []IndexDocument, onBatch func(indexed int)) error {
+	index, err := bleve.New(path, indexMapping)
+	if err != nil {
+		return err
+	}
+
+	linked := withLinks(documents)
+	for start := 0; start < len(linked); start += indexBatchSize {
+		end := min(start+indexBatchSize, len(linked))
+		batch := index.NewBatch()
+		for _, doc := range linked[start:end] {
+			if err := batch.Index(doc.ID, doc); err != nil {
+				index.Close()
+				return err
+			}
+		}
+		if err := index.Batch(batch); err != nil {
+			index.Close()
+			return err
+		}
+		if onBatch != nil {
+			onBatch(end)
+		}
+	}
+	if err := setSchemaVersion(index); err != nil {
+		index.Close()
+		return err
+	}
+
+	return index.Close()
+}
+
+func setSchemaVersion(index bleve.Index) error {
+	return index.SetInternal(schemaVersionKey, []byte(strconv.Itoa(IndexSchemaVersion)))
+}
+
+// SchemaVersion은 열린 인덱스에 기록된 스키마 버전을 반환합니다.
+// 버전이 기록되기 전에 만들어진 인덱스는 0을 반환합니다.
+func (im *IndexManager) SchemaVersion() (int, error) {
+	if im.index == nil {
+		return 0, fmt.Errorf("index is not open")
+	}
+
+	value, err := im.index.GetInternal(schemaVersionKey)
+	if err != nil {
+		return 0, err
+	}
+	if len(value) == 0 {
+		return 0, nil
+	}
+
+	return strconv.Atoi(string(value))
+}
+
+func (im *IndexManager) isOpen() bool {
+	return im.index != nil
+}
+
+// publish는 잠금을 잡은 상태에서 stagingPath를 indexPath로 교체합니다.
+// 기존 인덱스는 옆으로 옮겨 두고 아무도 쓰지 않을 때 삭제하며, 교체에 실패하면 원래 자리로 되돌립니다.
+// 기존 인덱스를 옮기지 못하면(Windows에서 다른 프로세스가 열고 있는 경우 등) 기존 인덱스를 그대로 두고 에러를 반환합니다.
+func (im *IndexManager) publish(stagingPath string) error {
+	lock, err := filelock.Exclusive(im.lockPath())
+	if err != nil {
+		return err
+	}
+	defer lock.Unlock()
+
+	retiredPath := ""
+	if _, err := os.Stat(im.indexPath); err == nil {
+		retiredPath = fmt.Sprintf("%s%s%d-%d", im.indexPath, indexRetiredSuffix, os.Getpid(), time.Now().UnixNano())
+		if err := os.Rename(im.indexPath, retiredPath); err != nil {
+			return fmt.Errorf("cannot move the current index aside, keeping it (another process may have it open): %w", err)
+		}
+	}
+
+	if err := os.Rename(stagingPath, im.indexPath); err != nil {
+		if retiredPath != "" {
+			_ = os.Rename(retiredPath, im.indexPath)
+		}
+		return fmt.Errorf("cannot move the new index into place, keeping the current one: %w", err)
+	}
+
+	im.removeLeftovers()
+	return nil
+}
+
+// removeLeftovers는 이전 교체나 중단된 빌드가 남긴 디렉터리를 정리합니다.
+// 교체로 옮겨진 인덱스는 다른 프로세스가 아직 열고 있으면 건너뛰고, 다음 교체나 OpenIndex 때 다시 시도합니다.
+func (im *IndexManager) removeLeftovers() {
+	entries, err := os.ReadDir(filepath.Dir(im.indexPath))
+	if err != nil {
+		return
+	}
+
+	base := filepath.Base(im.indexPath)
+	for _, entry := range entries {
+		name := entry.Name()
+		path := filepath.Join(filepath.Dir(im.indexPath), name)
+
+		switch {
+		case strings.HasPrefix(name, base+indexRetiredSuffix):
+			removeIfUnused(path)
+		case strings.HasPrefix(name, base+indexStagingSuffix):
+			info, err := entry.Info()
+			if err == nil && time.Since(info.ModTime()) > staleStagingAge {
+				_ = os.RemoveAll(path)
+			}
+		}
+	}
+}
+
+// removeIfUnused는 path 인덱스 디렉터리를 연 프로세스가 없을 때만 삭제합니다.
+// 옮겨진 디렉터리는 새로 열리지 않으므로, 사용 중 잠금을 한 번 배타적으로 얻으면 잠금을 풀고 지워도 됩니다.
+func removeIfUnused(path string) {
+	lock, ok, err := filelock.TryExclusive(filepath.Join(path, indexInUseFile))
+	if err != nil || !ok {
+		return
+	}
+	_ = lock.Unlock()
+	_ = os.RemoveAll(path)
+}
+
+// OpenIndex는 indexPath의 인덱스를 읽기 전용으로 엽니다.
+// 다른 프로세스가 인덱스를 교체하는 중이면 교체가 끝날 때까지 기다리며, Close할 때까지 인덱스 디렉터리에 사용 중 잠금을 잡습니다.
 func (im *IndexManager) OpenIndex() error {
-	index, err := bleve.Open(im.indexPath)
+	lock, err := filelock.Shared(im.lockPath())
+	if err != nil {
+		return err
+	}
+	defer lock.Unlock()
+
+	index, err := bleve.OpenUsing(im.indexPath, map[string]interface{}{
+		"read_only":    true,
+		"bolt_timeout": indexOpenTimeout,
+	})
+	if err != nil {
+		return err
+	}
+
+	inUse, err := filelock.Shared(filepath.Join(im.indexPath, indexInUseFile))
 	if err != nil {
+		index.Close()
 		return err
 	}
 
 	im.index = index
+	im.inUse = inUse
+	im.removeLeftovers()
 	return nil
 }
 
+func (im *IndexManager) lockPath() string {
+	return im.indexPath + indexLockSuffix
+}
+
 func (im *IndexManager) IndexDocuments(documents []IndexDocument) error {
 	batch := im.index.NewBatch()
 
-	for _, doc := range documents {
+	for _, doc := range withLinks(documents) {
 		if err := batch.Index(doc.ID, doc); err != nil {
 			return err
 		}
@@ -291,7 +550,7 @@ func generateDocID(index int, url string) string {
 func (im *IndexManager) GetByID(id string) (*IndexDocument, error) {
 	query := bleve.NewDocIDQuery([]string{id})
 	searchRequest := bleve.NewSearchRequest(query)
-	searchRequest.Fields = []string{"title", "content", "description", "url", "category"}
+	searchRequest.Fields = []string{"title", "content", "description", "url", "category", "links"}
 
 	searchResult, err := im.index.Search(searchRequest)
 	if err != nil {
@@ -319,6 +578,7 @@ func (im *IndexManager) GetByID(id string) (*IndexDocument, error) {
 	if v, ok := hit.Fields["category"].(string); ok {
 		doc.Category = v
 	}
+	doc.Links = storedStrings(hit.Fields["links"])
 
 	return doc, nil
 }
@@ -342,6 +602,8 @@ type FieldBoosts struct {
 	Description float64
 	Content     float64
 	Category    float64
+	// Links는 필드 부스트가 아니라 역링크 수에 따른 점수 배율입니다 (0이면 사용하지 않음)
+	Links float64
 }
 
 // DefaultFieldBoosts는 기본 필드 가중치를 반환합니다
@@ -351,6 +613,7 @@ func DefaultFieldBoosts() FieldBoosts {
 		Description: DefaultDescriptionBoost,
 		Content:     DefaultContentBoost,
 		Category:    DefaultCategoryBoost,
+		Links:       DefaultLinkBoost,
 	}
 }
 
@@ -370,12 +633,15 @@ func (fb FieldBoosts) validate() error {
 	sum := 0.0
 	for _, f := range fields {
 		if math.IsNaN(f.value) || f.value < 0 || f.value > MaxFieldBoost {
-			return fmt.Errorf("%s boost must be a number between 0 and %v, got %v", f.name, float64(MaxFieldBoost), f.value)
+			return InvalidArgument(fmt.Errorf("%s boost must be a number between 0 and %v, got %v", f.name, float64(MaxFieldBoost), f.value))
 		}
 		sum += f.value
 	}
 	if sum == 0 {
-		return fmt.Errorf("at least one field boost must be > 0")
+		return InvalidArgument(fmt.Errorf("at least one field boost must be > 0"))
+	}
+	if math.IsNaN(fb.Links) || fb.Links < 0 || fb.Links > MaxFieldBoost {
+		return InvalidArgument(fmt.Errorf("link boost must be a number between 0 and %v, got %v", float64(MaxFieldBoost), fb.Links))
 	}
 	return nil
 }
@@ -390,33 +656,47 @@ func (im *IndexManager) SearchWithBoosts(query string, limit int, boosts FieldBo
 	}
 
 	// 여러 필드에서 검색하기 위해 DisjunctionQuery 사용
-	// 검색용 analyzer 사용 (edgengram 제외)
-	titleQuery := bleve.NewMatchQuery(query)
+	return im.searchRanked(matchAllFields(query, boosts), limit, boosts.Links, false)
+}
+
+// matchAllFields는 query를 title/description/content/category 필드 각각에 매칭하는 DisjunctionQuery를 만듭니다.
+// 검색용 analyzer(edgengram 제외)를 사용하며, description과 content에는 오타 허용(fuzziness)을 적용합니다.
+func matchAllFields(text string, boosts FieldBoosts) query.Query {
+	titleQuery := bleve.NewMatchQuery(text)
 	titleQuery.SetField("title")
 	titleQuery.Analyzer = "cjk_search"
 	titleQuery.SetBoost(boosts.Title)
 
-	descQuery := bleve.NewMatchQuery(query)
+	descQuery := bleve.NewMatchQuery(text)
 	descQuery.SetField("description")
 	descQuery.Analyzer = "cjk_search"
 	descQuery.SetAutoFuzziness(true)
 	descQuery.SetPrefix(1)
 	descQuery.SetBoost(boosts.Description)
 
-	contentQuery := bleve.NewMatchQuery(query)
+	contentQuery := bleve.NewMatchQuery(text)
 	contentQuery.SetField("content")
 	contentQuery.Analyzer = "cjk_search"
 	contentQuery.SetAutoFuzziness(true)
 	contentQuery.SetPrefix(1)
 	contentQuery.SetBoost(boosts.Content)
 
-	categoryQuery := bleve.NewMatchQuery(query)
+	categoryQuery := bleve.NewMatchQuery(text)
 	categoryQuery.SetField("category")
 	categoryQuery.Analyzer = "cjk_search"
 	categoryQuery.SetBoost(boosts.Category)
 
-	searchQuery := bleve.NewDisjunctionQuery(titleQuery, descQuery, contentQuery, categoryQuery)
-	searchRequest := bleve.NewSearchRequestOptions(searchQuery, limit, 0, false)
+	return bleve.NewDisjunctionQuery(titleQuery, descQuery, contentQuery, categoryQuery)
+}
+
+// SearchQuery는 미리 구성한 bleve 쿼리로 검색합니다
+func (im *IndexManager) SearchQuery(searchQuery query.Query, limit int) ([]IndexDocument, []float64, error) {
+	return im.searchQuery(searchQuery, limit, false)
+}
+
+// searchQuery는 SearchQuery와 같고, explain이면 각 문서에 bleve 점수 설명을 함께 담습니다
+func (im *IndexManager) searchQuery(searchQuery query.Query, limit int, explain bool) ([]IndexDocument, []float64, error) {
+	searchRequest := bleve.NewSearchRequestOptions(searchQuery, limit, 0, explain)
 	searchRequest.Fields = []string{"title", "content", "description", "url", "category"}
 
 	searchResult, err := im.index.Search(searchRequest)
@@ -429,7 +709,8 @@ func (im *IndexManager) SearchWithBoosts(query string, limit int, boosts FieldBo
 
 	for _, hit := range searchResult.Hits {
 		doc := IndexDocument{
-			ID: hit.ID,
+			ID:          hit.ID,
+			explanation: hit.Expl,
 		}
 
 		if title, ok := hit.Fields["title"].(string); ok {
@@ -456,8 +737,14 @@ func (im *IndexManager) SearchWithBoosts(query string, limit int, boosts FieldBo
 }
 
 func (im *IndexManager) Close() error {
+	var err error
 	if im.index != nil {
-		return im.index.Close()
+		err = im.index.Close()
+		im.index = nil
 	}
-	return nil
+	if im.inUse != nil {
+		_ = im.inUse.Unlock()
+		im.inUse = nil
+	}
+	return err
 }
@@ -0,0 +1,286 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/search/query"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/text"
+)
+
+const (
+	// DefaultLinkBoost는 다른 문서에서 많이 링크된 문서에 주는 가중치입니다.
+	// 점수에 1 + LinkBoost * ln(1 + 역링크 수)를 곱합니다.
+	DefaultLinkBoost = 0.1
+
+	// 역링크 가중치를 적용할 때 limit보다 몇 배 많은 후보를 가져와 다시 정렬할지 정합니다
+	linkRerankFactor = 2
+
+	// 한 문서의 역링크를 조회할 때 최대 개수입니다
+	maxBacklinks = 100
+)
+
+var linkParser = goldmark.New()
+
+// normalizeLinkKey는 문서 URL이나 본문 링크를 코퍼스와 무관하게 비교할 수 있는 형태로 정규화합니다.
+// 상대 경로는 baseURL 기준으로 해석하고(tdsURLTransform과 같은 방식), 스킴은 https로,
+// 호스트는 소문자로 맞추며, 쿼리/프래그먼트와 .md/.html 확장자, 끝의 /index와 /를 제거합니다.
+// http(s)가 아닌 링크나 같은 문서 안의 앵커는 빈 문자열을 반환합니다.
+func normalizeLinkKey(raw, baseURL string) string {
+	raw = strings.TrimSpace(raw)
+	if raw == "" || strings.HasPrefix(raw, "#") {
+		return ""
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		return ""
+	}
+	if baseURL != "" && !u.IsAbs() {
+		base, err := url.Parse(baseURL)
+		if err != nil {
+			return ""
+		}
+		u = base.ResolveReference(u)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+		return ""
+	}
+
+	host := strings.TrimPrefix(strings.ToLower(u.Host), "www.")
+	path := u.Path
+	for _, ext := range []string{".md", ".mdx", ".html", ".htm"} {
+		if strings.HasSuffix(strings.ToLower(path), ext) {
+			path = path[:len(path)-len(ext)]
+			break
+		}
+	}
+	path = strings.TrimSuffix(path, "/index")
+	path = strings.TrimSuffix(path, "/")
+
+	return "https://" + host + path
+}
+
+// extractLinks는 마크다운 본문에서 다른 페이지로 가는 링크를 찾아 정규화된 키로 반환합니다.
+// 코드 블록 안의 링크 형태 문자열은 무시하며, 자기 자신으로 가는 링크와 중복은 제외합니다.
+func extractLinks(content, baseURL string) []string {
+	source := []byte(content)
+	doc := linkParser.Parser().Parse(text.NewReader(source))
+
+	self := normalizeLinkKey(baseURL, "")
+	seen := map[string]bool{}
+	var links []string
+
+	ast.Walk(doc, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+
+		var destination string
+		switch n := node.(type) {
+		case *ast.Link:
+			destination = string(n.Destination)
+		case *ast.AutoLink:
+			if n.AutoLinkType != ast.AutoLinkURL {
+				return ast.WalkContinue, nil
+			}
+			destination = string(n.URL(source))
+		default:
+			return ast.WalkContinue, nil
+		}
+
+		key := normalizeLinkKey(destination, baseURL)
+		if key != "" && key != self && !seen[key] {
+			seen[key] = true
+			links = append(links, key)
+		}
+		return ast.WalkContinue, nil
+	})
+
+	return links
+}
+
+// withLinks는 각 문서의 링크 키와 본문 링크를 채운 사본을 반환합니다
+func withLinks(documents []IndexDocument) []IndexDocument {
+	linked := make([]IndexDocument, len(documents))
+	for i, doc := range documents {
+		doc.LinkKey = normalizeLinkKey(doc.URL, "")
+		doc.Links = extractLinks(doc.Content, doc.URL)
+		linked[i] = doc
+	}
+	return linked
+}
+
+// storedStrings는 저장된 필드 값을 문자열 목록으로 변환합니다.
+// bleve는 값이 하나인 배열 필드를 문자열 하나로 돌려줍니다.
+func storedStrings(value any) []string {
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	default:
+		return nil
+	}
+}
+
+// searchRanked는 searchQuery로 검색한 뒤, linkBoost가 양수이면 역링크 수에 따라 점수를 조정해 다시 정렬합니다.
+// explain이면 같은 검색 요청에서 bleve 점수 설명을 받아 각 문서에 담습니다 (역링크 보정 전 점수의 설명).
+func (im *IndexManager) searchRanked(searchQuery query.Query, limit int, linkBoost float64, explain bool) ([]IndexDocument, []float64, error) {
+	if linkBoost <= 0 {
+		return im.searchQuery(searchQuery, limit, explain)
+	}
+
+	docs, scores, err := im.searchQuery(searchQuery, limit*linkRerankFactor, explain)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	counts, err := im.backlinkCounts(docs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	order := make([]int, len(docs))
+	for i := range order {
+		order[i] = i
+		scores[i] *= 1 + linkBoost*math.Log1p(float64(counts[i]))
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return scores[order[a]] > scores[order[b]]
+	})
+	if len(order) > limit {
+		order = order[:limit]
+	}
+
+	rankedDocs := make([]IndexDocument, len(order))
+	rankedScores := make([]float64, len(order))
+	for i, idx := range order {
+		rankedDocs[i] = docs[idx]
+		rankedScores[i] = scores[idx]
+	}
+	return rankedDocs, rankedScores, nil
+}
+
+// backlinkCounts는 각 문서를 링크하는 같은 인덱스 안의 문서 수를 반환합니다
+func (im *IndexManager) backlinkCounts(docs []IndexDocument) ([]uint64, error) {
+	counts := make([]uint64, len(docs))
+	if len(docs) == 0 {
+		return counts, nil
+	}
+
+	advanced, err := im.index.Advanced()
+	if err != nil {
+		return nil, err
+	}
+	reader, err := advanced.Reader()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	for i, doc := range docs {
+		key := normalizeLinkKey(doc.URL, "")
+		if key == "" {
+			continue
+		}
+		if counts[i], err = termDocFrequency(reader, key, "links"); err != nil {
+			return nil, err
+		}
+	}
+	return counts, nil
+}
+
+// DocumentLinks는 한 문서의 링크 정보입니다
+type DocumentLinks struct {
+	ID       string
+	LinkKey  string   // 다른 코퍼스에서 이 문서를 찾을 때 사용하는 정규화된 URL
+	Outlinks []string // 본문 링크의 정규화된 URL
+}
+
+// DocumentLinks는 id 문서의 링크 키와 본문 링크를 반환합니다
+func (s *Searcher) DocumentLinks(ctx context.Context, id string) (*DocumentLinks, error) {
+	doc, err := s.indexManager.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if doc == nil {
+		return nil, fmt.Errorf("%w: %s", ErrDocumentNotFound, id)
+	}
+
+	return &DocumentLinks{
+		ID:       doc.ID,
+		LinkKey:  normalizeLinkKey(doc.URL, ""),
+		Outlinks: doc.Links,
+	}, nil
+}
+
+// ResolveLinks는 정규화된 URL 목록 중 이 인덱스에 있는 문서를 찾아 키별로 반환합니다
+func (s *Searcher) ResolveLinks(ctx context.Context, keys []string) (map[string]SearchResult, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	terms := make([]query.Query, len(keys))
+	for i, key := range keys {
+		termQuery := bleve.NewTermQuery(key)
+		termQuery.SetField("link_key")
+		terms[i] = termQuery
+	}
+
+	docs, _, err := s.indexManager.SearchQuery(bleve.NewDisjunctionQuery(terms...), len(keys))
+	if err != nil {
+		return nil, err
+	}
+
+	resolved := make(map[string]SearchResult, len(docs))
+	for _, doc := range docs {
+		resolved[normalizeLinkKey(doc.URL, "")] = linkedResult(doc)
+	}
+	return resolved, nil
+}
+
+// Backlinks는 본문에서 linkKey 문서를 링크하는 이 인덱스의 문서를 반환합니다
+func (s *Searcher) Backlinks(ctx context.Context, linkKey string) ([]SearchResult, error) {
+	if linkKey == "" {
+		return nil, nil
+	}
+
+	termQuery := bleve.NewTermQuery(linkKey)
+	termQuery.SetField("links")
+
+	docs, _, err := s.indexManager.SearchQuery(termQuery, maxBacklinks)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]SearchResult, len(docs))
+	for i, doc := range docs {
+		results[i] = linkedResult(doc)
+	}
+	return results, nil
+}
+
+// linkedResult는 링크 목록에 표시할 최소한의 문서 정보만 남깁니다
+func linkedResult(doc IndexDocument) SearchResult {
+	return SearchResult{
+		ID:          doc.ID,
+		Title:       doc.Title,
+		Description: doc.Description,
+		URL:         doc.URL,
+		Category:    doc.Category,
+	}
+}
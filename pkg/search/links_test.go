@@ -0,0 +1,116 @@
+package search
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestNormalizeLinkKey(t *testing.T) {
+	tests := []struct {
+		raw  string
+		base string
+		want string
+	}{
+		{"https://developers-apps-in-toss.toss.im/bedrock/reference/framework/화면제어/IOScrollView.md", "", "https://developers-apps-in-toss.toss.im/bedrock/reference/framework/화면제어/IOScrollView"},
+		{"/bedrock/reference/framework/화면제어/IOScrollView.html#props", "https://developers-apps-in-toss.toss.im/tutorials/start.md", "https://developers-apps-in-toss.toss.im/bedrock/reference/framework/화면제어/IOScrollView"},
+		{"./refund.md", "https://developers-apps-in-toss.toss.im/tosspay/intro.md", "https://developers-apps-in-toss.toss.im/tosspay/refund"},
+		{"/tds-react-native/components/button/", "https://tossmini-docs.toss.im/tds-react-native/components/toast/", "https://tossmini-docs.toss.im/tds-react-native/components/button"},
+		{"http://WWW.Tossmini-Docs.toss.im/tds-mobile/index.html?tab=1", "", "https://tossmini-docs.toss.im/tds-mobile"},
+		{"#props", "https://example.com/a", ""},
+		{"mailto:dev@toss.im", "https://example.com/a", ""},
+		{"/relative/without/base", "", ""},
+	}
+
+	for _, tt := range tests {
+		if got := normalizeLinkKey(tt.raw, tt.base); got != tt.want {
+			t.Errorf("normalizeLinkKey(%q, %q) = %q, want %q", tt.raw, tt.base, got, tt.want)
+		}
+	}
+}
+
+func TestExtractLinks(t *testing.T) {
+	content := "결제가 끝나면 [환불](./refund.md)과 [에러 코드](/tosspay/errors.html)를 확인하세요.\n" +
+		"버튼은 [TDS Button](https://tossmini-docs.toss.im/tds-react-native/components/button/)을 사용합니다.\n" +
+		"[환불](./refund.md#api)은 다시 링크되어도 한 번만 기록됩니다. [이 문서](#top), [자기 자신](./intro.md)\n" +
+		"<https://developers-apps-in-toss.toss.im/tosspay/faq.md>\n\n" +
+		"```md\n[코드 블록](./ignored.md)\n```\n"
+
+	got := extractLinks(content, "https://developers-apps-in-toss.toss.im/tosspay/intro.md")
+	want := []string{
+		"https://developers-apps-in-toss.toss.im/tosspay/refund",
+		"https://developers-apps-in-toss.toss.im/tosspay/errors",
+		"https://tossmini-docs.toss.im/tds-react-native/components/button",
+		"https://developers-apps-in-toss.toss.im/tosspay/faq",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("extractLinks = %v, want %v", got, want)
+	}
+}
+
+func TestSearcher_LinkGraph(t *testing.T) {
+	s, tempDir := testSearcher(t, appsInTossIndexer, nil)
+	defer os.RemoveAll(tempDir)
+
+	if err := s.indexManager.CreateIndex(); err != nil {
+		t.Fatalf("Failed to create index: %v", err)
+	}
+	defer s.Close()
+
+	docs := []IndexDocument{
+		{ID: "intro", Title: "토스페이 소개", URL: "https://example.com/tosspay/intro.md", Content: "토스페이 결제 흐름입니다. [환불](./refund.md), [TDS Button](https://tossmini-docs.toss.im/tds-react-native/components/button/)"},
+		{ID: "refund", Title: "토스페이 환불", URL: "https://example.com/tosspay/refund.md", Content: "환불 방법입니다."},
+		{ID: "faq", Title: "토스페이 결제 자주 묻는 질문", URL: "https://example.com/tosspay/faq.md", Content: "토스페이 결제 질문 모음입니다. [환불](refund.md)"},
+	}
+	if err := s.indexManager.IndexDocuments(docs); err != nil {
+		t.Fatalf("Failed to index: %v", err)
+	}
+
+	links, err := s.DocumentLinks(context.Background(), "intro")
+	if err != nil {
+		t.Fatalf("DocumentLinks failed: %v", err)
+	}
+	wantOutlinks := []string{
+		"https://example.com/tosspay/refund",
+		"https://tossmini-docs.toss.im/tds-react-native/components/button",
+	}
+	if links.LinkKey != "https://example.com/tosspay/intro" || !reflect.DeepEqual(links.Outlinks, wantOutlinks) {
+		t.Errorf("Unexpected links: %+v", links)
+	}
+
+	// 같은 인덱스에 있는 링크만 문서로 해석되어야 함
+	resolved, err := s.ResolveLinks(context.Background(), links.Outlinks)
+	if err != nil {
+		t.Fatalf("ResolveLinks failed: %v", err)
+	}
+	if len(resolved) != 1 || resolved["https://example.com/tosspay/refund"].ID != "refund" {
+		t.Errorf("Unexpected resolved links: %+v", resolved)
+	}
+
+	backlinks, err := s.Backlinks(context.Background(), "https://example.com/tosspay/refund")
+	if err != nil {
+		t.Fatalf("Backlinks failed: %v", err)
+	}
+	if len(backlinks) != 2 {
+		t.Errorf("Expected 2 backlinks to refund, got %+v", backlinks)
+	}
+
+	// 역링크가 가장 많은 refund 문서가 링크 가중치로 먼저 나와야 함
+	results, err := s.Search(context.Background(), "토스페이 결제", &SearchOptions{Boosts: BoostOverrides{Links: floatPtr(20)}})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(results) == 0 || results[0].ID != "refund" {
+		t.Errorf("Expected refund first with link boost, got %+v", results)
+	}
+
+	// 링크 가중치를 끄면 본문 점수만으로 정렬되어 refund가 가장 앞서지 않아야 함
+	plain, err := s.Search(context.Background(), "토스페이 결제", &SearchOptions{Boosts: BoostOverrides{Links: floatPtr(0)}})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(plain) == 0 || plain[0].ID == "refund" {
+		t.Errorf("Expected another document first without link boost, got %+v", plain)
+	}
+}
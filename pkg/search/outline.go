@@ -0,0 +1,123 @@
+package search
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/text"
+)
+
+var outlineParser = goldmark.New()
+
+// OutlineSection은 문서 목차의 제목 하나와 그 아래 본문(하위 절 포함)의 크기입니다.
+// 목차는 문서 순서의 평평한 목록이며, Parent로 상위 제목을 가리켜 트리를 나타냅니다.
+type OutlineSection struct {
+	Title string `json:"title" jsonschema:"Heading text"`
+	// Level은 마크다운 제목 단계(1-6), Depth는 목차 트리에서의 깊이(0부터)입니다.
+	// 제목 단계를 건너뛰는 문서(# 다음 ###)에서는 둘이 다를 수 있습니다.
+	Level int `json:"level" jsonschema:"Markdown heading level (1-6)"`
+	Depth int `json:"depth" jsonschema:"Depth in the outline tree, starting at 0"`
+	// Parent는 상위 제목의 목록 내 위치입니다. 최상위 제목이면 -1입니다.
+	Parent int `json:"parent" jsonschema:"Index of the parent heading in sections, -1 at the top"`
+	// Anchor는 get_doc의 section 인자로 넘길 수 있는 앵커입니다. 같은 제목이 다시 나오면 GitHub처럼 -1, -2를 붙입니다.
+	Anchor string `json:"anchor" jsonschema:"Anchor to pass as section to get_doc"`
+	// Line은 제목 줄 번호(1부터), Offset은 제목의 본문 내 위치(문자 단위)입니다
+	Line   int `json:"line" jsonschema:"Line number of the heading (1-based)"`
+	Offset int `json:"offset" jsonschema:"Character offset of the heading, usable as offset for get_doc"`
+	// Chars와 Tokens는 제목부터 같은 단계 이상의 다음 제목 전까지의 문자 수와 추정 토큰 수입니다
+	Chars  int `json:"chars" jsonschema:"Characters in the section including subsections"`
+	Tokens int `json:"tokens" jsonschema:"Estimated tokens of the section including subsections"`
+}
+
+// Outline은 마크다운 본문을 goldmark로 파싱해 제목 목차를 만듭니다. 코드 블록 안의 # 줄은 제목으로 보지 않습니다.
+func Outline(content string) []OutlineSection {
+	source := []byte(content)
+	doc := outlineParser.Parser().Parse(text.NewReader(source))
+
+	type heading struct {
+		level int
+		title string
+		start int // 제목 줄의 시작 위치 (바이트)
+	}
+	var headings []heading
+	ast.Walk(doc, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		h, ok := node.(*ast.Heading)
+		if !ok || h.Lines().Len() == 0 {
+			return ast.WalkContinue, nil
+		}
+
+		var title strings.Builder
+		for i := 0; i < h.Lines().Len(); i++ {
+			line := h.Lines().At(i)
+			title.Write(line.Value(source))
+		}
+		start := h.Lines().At(0).Start
+		start = strings.LastIndexByte(content[:start], '\n') + 1
+		headings = append(headings, heading{level: h.Level, title: strings.TrimSpace(title.String()), start: start})
+		return ast.WalkSkipChildren, nil
+	})
+
+	sections := make([]OutlineSection, len(headings))
+	var stack []int // 현재 제목의 상위 제목들의 위치
+	anchors := map[string]int{}
+	for i, h := range headings {
+		for len(stack) > 0 && headings[stack[len(stack)-1]].level >= h.level {
+			stack = stack[:len(stack)-1]
+		}
+		parent := -1
+		if len(stack) > 0 {
+			parent = stack[len(stack)-1]
+		}
+		stack = append(stack, i)
+
+		// 절은 같은 단계 이상의 다음 제목 전까지입니다
+		end := len(content)
+		for _, next := range headings[i+1:] {
+			if next.level <= h.level {
+				end = next.start
+				break
+			}
+		}
+
+		body := content[h.start:end]
+		sections[i] = OutlineSection{
+			Title:  h.title,
+			Level:  h.level,
+			Depth:  len(stack) - 1,
+			Parent: parent,
+			Anchor: uniqueAnchor(headingAnchor(h.title), anchors),
+			Line:   strings.Count(content[:h.start], "\n") + 1,
+			Offset: utf8.RuneCountInString(content[:h.start]),
+			Chars:  utf8.RuneCountInString(body),
+			Tokens: EstimateTokens(body),
+		}
+	}
+	return sections
+}
+
+// uniqueAnchor는 GitHub의 제목 앵커처럼, 이미 나온 앵커면 -1, -2 ...를 붙여 아직 쓰지 않은 앵커를 만듭니다.
+// seen은 앵커마다 마지막으로 붙인 번호를 기록합니다.
+func uniqueAnchor(anchor string, seen map[string]int) string {
+	n, ok := seen[anchor]
+	if !ok {
+		seen[anchor] = 0
+		return anchor
+	}
+	unique := anchor
+	for {
+		n++
+		unique = fmt.Sprintf("%s-%d", anchor, n)
+		if _, taken := seen[unique]; !taken {
+			break
+		}
+	}
+	seen[anchor] = n
+	seen[unique] = 0
+	return unique
+}
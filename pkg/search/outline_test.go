@@ -0,0 +1,111 @@
+package search
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestOutline(t *testing.T) {
+	sections := Outline(pagedDoc)
+
+	want := []struct {
+		title         string
+		depth, parent int
+		line          int
+	}{
+		{"결제 연동", 0, -1, 1},
+		{"설치", 1, 0, 5},
+		{"에러 코드", 1, 0, 16},
+	}
+	if len(sections) != len(want) {
+		t.Fatalf("Expected %d headings without the # line in the code block, got %+v", len(want), sections)
+	}
+	for i, w := range want {
+		s := sections[i]
+		if s.Title != w.title || s.Depth != w.depth || s.Parent != w.parent || s.Line != w.line {
+			t.Errorf("Section %d: expected %+v, got %+v", i, w, s)
+		}
+	}
+
+	runes := []rune(pagedDoc)
+	if sections[0].Offset != 0 || sections[0].Chars != len(runes) {
+		t.Errorf("Expected the top section to cover the whole document, got %+v", sections[0])
+	}
+	install := sections[1]
+	if got := string(runes[install.Offset : install.Offset+install.Chars]); got[:len("## 설치")] != "## 설치" || sections[2].Offset != install.Offset+install.Chars {
+		t.Errorf("Expected the install section to end where the next one starts, got %q", got)
+	}
+	if install.Tokens != EstimateTokens(string(runes[install.Offset:install.Offset+install.Chars])) {
+		t.Errorf("Unexpected token estimate %d", install.Tokens)
+	}
+	if sections[2].Anchor != "에러-코드" {
+		t.Errorf("Expected anchor 에러-코드, got %q", sections[2].Anchor)
+	}
+
+	// 제목 단계를 건너뛰어도 트리 깊이는 1씩 늘어납니다
+	skipped := Outline("# A\n\n### B\n\n## C\n")
+	if skipped[1].Level != 3 || skipped[1].Depth != 1 || skipped[2].Depth != 1 || skipped[2].Parent != 0 {
+		t.Errorf("Unexpected outline for skipped levels: %+v", skipped)
+	}
+}
+
+func TestOutline_AnchorsResolveInPaginateContent(t *testing.T) {
+	content := pagedDoc + `
+설정
+====
+
+앱 설정을 바꿉니다.
+
+환경 변수
+---------
+
+` + "```\n# 주석\n```" + `
+
+### 문의 (Contact)
+
+메일로 문의하세요.
+`
+	sections := Outline(content)
+	if len(sections) != 6 {
+		t.Fatalf("Expected 6 headings including setext ones, got %+v", sections)
+	}
+	for _, s := range sections {
+		page, err := PaginateContent(content, PageOptions{Section: s.Anchor})
+		if err != nil {
+			t.Errorf("Section %q: %v", s.Anchor, err)
+			continue
+		}
+		if page.Offset != s.Offset {
+			t.Errorf("Section %q: expected offset %d, got %d", s.Anchor, s.Offset, page.Offset)
+		}
+	}
+}
+
+func TestOutline_DuplicateHeadingAnchors(t *testing.T) {
+	content := "# 설치\n\n## 설치\n\n첫 번째\n\n## 설치 1\n\n## 설치\n\n두 번째\n"
+
+	sections := Outline(content)
+	var anchors []string
+	for _, s := range sections {
+		anchors = append(anchors, s.Anchor)
+	}
+	if got := strings.Join(anchors, ","); got != "설치,설치-1,설치-1-1,설치-2" {
+		t.Fatalf("Unexpected anchors %s", got)
+	}
+
+	for _, s := range sections {
+		page, err := PaginateContent(content, PageOptions{Section: s.Anchor})
+		if err != nil {
+			t.Errorf("Section %q: %v", s.Anchor, err)
+			continue
+		}
+		if page.Offset != s.Offset {
+			t.Errorf("Section %q: expected offset %d, got %d", s.Anchor, s.Offset, page.Offset)
+		}
+	}
+
+	page, err := PaginateContent(content, PageOptions{Section: "설치 1"})
+	if err != nil || page.Offset != sections[2].Offset {
+		t.Errorf("Expected the heading text to win over another heading's anchor, got offset %d, %v", page.Offset, err)
+	}
+}
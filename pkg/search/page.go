@@ -0,0 +1,337 @@
+package search
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// ErrSectionNotFound는 PageOptions.Section에 해당하는 제목이 문서에 없음을 나타냅니다
+var ErrSectionNotFound = errors.New("section not found")
+
+// PageOptions는 긴 문서를 나눠 읽을 때의 시작 위치와 크기 제한입니다. 0이면 제한하지 않습니다.
+type PageOptions struct {
+	// Offset은 시작 위치(문자 단위)입니다. 이전 페이지의 NextOffset을 그대로 넘기면 이어서 읽습니다.
+	Offset int
+	// Section은 시작할 제목의 텍스트나 앵커입니다 (예: "에러 코드", "에러-코드"). 지정하면 Offset은 무시합니다.
+	Section string
+	// MaxChars는 페이지의 최대 문자 수입니다
+	MaxChars int
+	// MaxTokens는 페이지의 최대 추정 토큰 수입니다 (EstimateTokens)
+	MaxTokens int
+}
+
+func (o PageOptions) limited() bool {
+	return o.MaxChars > 0 || o.MaxTokens > 0
+}
+
+// Page는 문서 본문의 한 부분입니다
+type Page struct {
+	Content string
+	// Offset과 End는 Content의 본문 내 위치(문자 단위)입니다
+	Offset int
+	End    int
+	// HasMore가 true이면 End부터 이어서 읽을 내용이 남아 있습니다
+	HasMore bool
+	// EstimatedTokens는 Content의 추정 토큰 수입니다
+	EstimatedTokens int
+	// TotalChars와 TotalTokens는 문서 전체의 문자 수와 추정 토큰 수입니다
+	TotalChars  int
+	TotalTokens int
+}
+
+// EstimateTokens는 text를 LLM에 넣었을 때의 토큰 수를 어림합니다.
+// 영문과 코드는 대략 4글자에 1토큰, 한글 등 비ASCII 문자는 글자마다 1토큰으로 계산합니다.
+func EstimateTokens(text string) int {
+	var counter tokenCounter
+	for _, r := range text {
+		counter.add(r)
+	}
+	return counter.tokens()
+}
+
+// contentBlock은 본문을 나눌 수 있는 단위(문단, 제목, 코드 블록)입니다. 위치는 문자 단위입니다.
+type contentBlock struct {
+	start, end int
+	code       bool
+	heading    string // 제목 블록이면 # 를 뗀 제목 텍스트
+	level      int    // 제목 단계 (1-6)
+}
+
+// splitBlocks는 마크다운 본문을 빈 줄과 제목을 경계로 블록으로 나눕니다.
+// 펜스 코드 블록(``` 또는 ~~~)은 안에 빈 줄이나 # 가 있어도 하나의 블록으로 둡니다.
+func splitBlocks(content string) []contentBlock {
+	var blocks []contentBlock
+	var current *contentBlock
+	fence := ""
+	offset := 0
+
+	flush := func() {
+		if current != nil {
+			blocks = append(blocks, *current)
+			current = nil
+		}
+	}
+
+	for _, line := range strings.SplitAfter(content, "\n") {
+		if line == "" {
+			continue
+		}
+		length := len([]rune(line))
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case fence != "":
+			current.end = offset + length
+			if strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, fence[:1]) == "" {
+				fence = ""
+				flush()
+			}
+		case isFenceLine(trimmed):
+			flush()
+			fence = fenceMarker(trimmed)
+			current = &contentBlock{start: offset, end: offset + length, code: true}
+		case trimmed == "":
+			// 빈 줄은 앞 블록에 붙이고 블록을 끝냅니다
+			if current != nil {
+				current.end = offset + length
+			} else if len(blocks) > 0 {
+				blocks[len(blocks)-1].end = offset + length
+			} else {
+				current = &contentBlock{start: offset, end: offset + length}
+			}
+			flush()
+		default:
+			if level, text := parseHeading(trimmed); level > 0 {
+				flush()
+				blocks = append(blocks, contentBlock{start: offset, end: offset + length, heading: text, level: level})
+			} else if current == nil {
+				current = &contentBlock{start: offset, end: offset + length}
+			} else {
+				current.end = offset + length
+			}
+		}
+		offset += length
+	}
+	flush()
+	return blocks
+}
+
+func isFenceLine(line string) bool {
+	return strings.HasPrefix(line, "```") || strings.HasPrefix(line, "~~~")
+}
+
+// fenceMarker는 여는 펜스의 문자 반복(``` 또는 ````, ~~~)을 반환합니다. 닫는 펜스는 이보다 짧을 수 없습니다.
+func fenceMarker(line string) string {
+	marker := line[:1]
+	n := len(line) - len(strings.TrimLeft(line, marker))
+	return strings.Repeat(marker, n)
+}
+
+// parseHeading은 ATX 제목("## 제목")의 단계와 텍스트를 반환합니다. 제목이 아니면 0입니다.
+func parseHeading(line string) (int, string) {
+	level := len(line) - len(strings.TrimLeft(line, "#"))
+	if level == 0 || level > 6 {
+		return 0, ""
+	}
+	rest := line[level:]
+	if rest != "" && rest[0] != ' ' && rest[0] != '\t' {
+		return 0, ""
+	}
+	return level, strings.TrimSpace(strings.TrimRight(strings.TrimSpace(rest), "#"))
+}
+
+// headingAnchor는 제목의 앵커를 만듭니다. 소문자로 바꾸고 공백은 -로, 글자/숫자/-/_ 외의 문자는 제거합니다.
+// 예: "에러 코드 (Error Codes)" → "에러-코드-error-codes"
+func headingAnchor(heading string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(strings.TrimSpace(heading)) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' || r == '_':
+			b.WriteRune(r)
+		case unicode.IsSpace(r):
+			b.WriteRune('-')
+		}
+	}
+	return b.String()
+}
+
+// PaginateContent는 content에서 opts의 위치부터 크기 제한 안에 들어가는 부분을 반환합니다.
+// 제목이나 문단 경계에서 자르며, 코드 블록은 중간에서 자르지 않습니다.
+// 시작 위치가 코드 블록 중간이면 그 블록의 처음부터 읽고, 블록 하나가 제한보다 크면
+// 문단은 줄 단위로 자르고 코드 블록은 통째로 반환합니다.
+func PaginateContent(content string, opts PageOptions) (Page, error) {
+	runes := []rune(content)
+	blocks := splitBlocks(content)
+	page := Page{
+		TotalChars:  len(runes),
+		TotalTokens: EstimateTokens(content),
+	}
+
+	first := len(blocks)
+	switch {
+	case opts.Section != "":
+		offset, ok := findSection(content, opts.Section)
+		if !ok {
+			return Page{}, fmt.Errorf("%w: %s", ErrSectionNotFound, opts.Section)
+		}
+		for i, block := range blocks {
+			if block.end > offset {
+				first = i
+				break
+			}
+		}
+		// setext 제목은 splitBlocks에서 문단 블록이므로 제목 줄부터 읽도록 시작 위치를 맞춥니다
+		if first < len(blocks) {
+			blocks[first].start = max(blocks[first].start, offset)
+		}
+	default:
+		for i, block := range blocks {
+			if block.end > max(opts.Offset, 0) {
+				first = i
+				break
+			}
+		}
+	}
+	if first >= len(blocks) {
+		page.Offset, page.End = len(runes), len(runes)
+		return page, nil
+	}
+
+	// 코드 블록 중간에서는 시작하지 않습니다. 문단은 줄이나 글자 단위로 잘린 다음 페이지일 수 있으므로 그 위치부터 읽습니다.
+	if !blocks[first].code && opts.Section == "" {
+		blocks[first].start = max(blocks[first].start, opts.Offset)
+	}
+	page.Offset = blocks[first].start
+	page.End = page.Offset
+	if !opts.limited() {
+		page.End = len(runes)
+	} else {
+		tokens, last := 0, -1
+		for i, block := range blocks[first:] {
+			blockTokens := EstimateTokens(string(runes[block.start:block.end]))
+			if !withinBudget(opts, block.end-page.Offset, tokens+blockTokens) {
+				break
+			}
+			page.End = block.end
+			tokens += blockTokens
+			last = first + i
+		}
+		// 페이지가 본문 없이 제목으로 끝나면 그 제목은 다음 페이지로 넘깁니다
+		if last > first && last < len(blocks)-1 && blocks[last].level > 0 {
+			page.End = blocks[last].start
+		}
+
+		switch {
+		case page.End == page.Offset:
+			page.End = cutOversizedBlock(runes, blocks[first], opts)
+		case last == first && blocks[first].level > 0 && first+1 < len(blocks):
+			// 제목 하나만 들어가면 다음 블록을 남은 제한만큼 잘라 함께 반환합니다
+			rest := PageOptions{MaxChars: opts.MaxChars - (page.End - page.Offset), MaxTokens: opts.MaxTokens - tokens}
+			if (opts.MaxChars <= 0 || rest.MaxChars > 0) && (opts.MaxTokens <= 0 || rest.MaxTokens > 0) && !blocks[first+1].code {
+				page.End = cutOversizedBlock(runes, blocks[first+1], rest)
+			}
+		}
+	}
+
+	page.Content = string(runes[page.Offset:page.End])
+	page.EstimatedTokens = EstimateTokens(page.Content)
+	page.HasMore = page.End < len(runes) && strings.TrimSpace(string(runes[page.End:])) != ""
+	return page, nil
+}
+
+// findSection은 텍스트나 앵커가 section과 같은 첫 제목의 위치(문자 단위)를 반환합니다.
+// get_doc_outline이 알려 주는 앵커와 어긋나지 않도록 제목은 Outline에서 찾으므로, 같은 제목이 여러 번 나오면
+// 텍스트로는 첫 제목을, "-1", "-2"가 붙은 앵커로는 그다음 제목들을 찾습니다.
+func findSection(content, section string) (int, bool) {
+	section = strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(section), "#"))
+	sections := Outline(content)
+	for _, s := range sections {
+		if strings.EqualFold(s.Title, section) {
+			return s.Offset, true
+		}
+	}
+	// 제목 "설치 1"의 앵커가 두 번째 "설치"의 앵커와 같아 보이지 않도록, 앵커는 제목 텍스트로 찾지 못했을 때만 비교합니다
+	anchor := headingAnchor(section)
+	for _, s := range sections {
+		if s.Anchor == anchor {
+			return s.Offset, true
+		}
+	}
+	return 0, false
+}
+
+func withinBudget(opts PageOptions, chars, tokens int) bool {
+	return (opts.MaxChars <= 0 || chars <= opts.MaxChars) && (opts.MaxTokens <= 0 || tokens <= opts.MaxTokens)
+}
+
+// cutOversizedBlock은 제한보다 큰 블록 하나의 끝 위치를 정합니다.
+// 코드 블록은 통째로, 문단은 제한 안에 들어가는 마지막 줄까지, 한 줄도 들어가지 않으면 제한 글자 수까지 자릅니다.
+func cutOversizedBlock(runes []rune, block contentBlock, opts PageOptions) int {
+	if block.code {
+		return block.end
+	}
+
+	var counter tokenCounter
+	lineEnd, charEnd := block.start, block.start+1
+	for i := block.start; i < block.end; i++ {
+		counter.add(runes[i])
+		if !withinBudget(opts, i+1-block.start, counter.tokens()) {
+			break
+		}
+		charEnd = i + 1
+		if runes[i] == '\n' {
+			lineEnd = i + 1
+		}
+	}
+	if lineEnd > block.start {
+		return lineEnd
+	}
+	// 줄 하나가 제한보다 길면 글자 단위로 자릅니다. 페이지가 비지 않도록 최소 한 글자는 포함합니다.
+	return charEnd
+}
+
+// tokenCounter는 EstimateTokens를 한 글자씩 늘려 가며 계산합니다
+type tokenCounter struct {
+	ascii, other int
+}
+
+func (c *tokenCounter) add(r rune) {
+	if r < 0x80 {
+		c.ascii++
+	} else {
+		c.other++
+	}
+}
+
+func (c *tokenCounter) tokens() int {
+	return (c.ascii+3)/4 + c.other
+}
+
+// ApplyTokenBudget은 여러 문서의 본문을 순서대로 읽으며 전체 추정 토큰 수가 maxTokens를 넘지 않도록 나눕니다.
+// 앞 문서부터 온전히 포함하고, 남은 예산을 넘는 문서는 PaginateContent로 잘라 HasMore를 표시하며,
+// 예산을 다 쓴 뒤의 문서는 내용 없이 HasMore만 표시합니다. maxTokens가 0 이하이면 모두 그대로 반환합니다.
+func ApplyTokenBudget(contents []string, maxTokens int) []Page {
+	pages := make([]Page, len(contents))
+	remaining := maxTokens
+	for i, content := range contents {
+		if maxTokens > 0 && remaining <= 0 {
+			pages[i] = Page{
+				HasMore:     content != "",
+				TotalChars:  len([]rune(content)),
+				TotalTokens: EstimateTokens(content),
+			}
+			continue
+		}
+
+		opts := PageOptions{}
+		if maxTokens > 0 {
+			opts.MaxTokens = remaining
+		}
+		// 시작 위치와 제목을 지정하지 않으므로 에러가 나지 않습니다
+		pages[i], _ = PaginateContent(content, opts)
+		remaining -= pages[i].EstimatedTokens
+	}
+	return pages
+}
@@ -0,0 +1,174 @@
+package search
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+const pagedDoc = `# 결제 연동
+
+토스페이 결제를 연동하는 방법입니다.
+
+## 설치
+
+패키지를 설치합니다.
+
+` + "```bash\nnpm install @apps-in-toss/framework\n\n# 설치 확인\nnpm ls\n```" + `
+
+## 에러 코드
+
+| 코드 | 설명 |
+| INVALID_PARAMETER | 잘못된 파라미터 |
+`
+
+func TestSplitBlocks_KeepsCodeBlocksWhole(t *testing.T) {
+	blocks := splitBlocks(pagedDoc)
+	runes := []rune(pagedDoc)
+
+	var headings []string
+	codeBlocks := 0
+	for _, b := range blocks {
+		if b.level > 0 {
+			headings = append(headings, b.heading)
+		}
+		if b.code {
+			codeBlocks++
+			if !strings.Contains(string(runes[b.start:b.end]), "npm ls") {
+				t.Errorf("Expected the whole code block, got %q", string(runes[b.start:b.end]))
+			}
+		}
+	}
+
+	if strings.Join(headings, "|") != "결제 연동|설치|에러 코드" {
+		t.Errorf("Unexpected headings %v (a # inside the code block must not be a heading)", headings)
+	}
+	if codeBlocks != 1 {
+		t.Errorf("Expected 1 code block, got %d", codeBlocks)
+	}
+}
+
+func TestPaginateContent(t *testing.T) {
+	t.Run("no limit returns everything", func(t *testing.T) {
+		page, err := PaginateContent(pagedDoc, PageOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if page.Content != pagedDoc || page.HasMore {
+			t.Errorf("Expected the whole document, got %+v", page)
+		}
+		if page.EstimatedTokens != EstimateTokens(pagedDoc) || page.TotalTokens != page.EstimatedTokens {
+			t.Errorf("Unexpected token counts %+v", page)
+		}
+	})
+
+	t.Run("pages follow each other without gaps and never split code", func(t *testing.T) {
+		var parts []string
+		offset := 0
+		for i := 0; i < 20; i++ {
+			page, err := PaginateContent(pagedDoc, PageOptions{Offset: offset, MaxChars: 40})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if page.Offset != offset {
+				t.Fatalf("Expected page to start at %d, got %d", offset, page.Offset)
+			}
+			if strings.Count(page.Content, "```")%2 != 0 {
+				t.Errorf("Page splits a code block: %q", page.Content)
+			}
+			if strings.HasSuffix(strings.TrimSpace(page.Content), "## 에러 코드") {
+				t.Errorf("Page ends with a dangling heading: %q", page.Content)
+			}
+			parts = append(parts, page.Content)
+			if !page.HasMore {
+				break
+			}
+			offset = page.End
+		}
+		if strings.Join(parts, "") != pagedDoc {
+			t.Errorf("Pages do not add up to the document:\n%q", parts)
+		}
+	})
+
+	t.Run("token budget", func(t *testing.T) {
+		page, err := PaginateContent(pagedDoc, PageOptions{MaxTokens: 30})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if page.EstimatedTokens > 30 || !page.HasMore {
+			t.Errorf("Expected a page within 30 tokens with more to read, got %+v", page)
+		}
+	})
+
+	t.Run("section cursor", func(t *testing.T) {
+		for _, section := range []string{"에러 코드", "## 에러 코드", "에러-코드"} {
+			page, err := PaginateContent(pagedDoc, PageOptions{Section: section})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.HasPrefix(page.Content, "## 에러 코드") {
+				t.Errorf("Section %q: expected page to start at the heading, got %q", section, page.Content)
+			}
+		}
+
+		if _, err := PaginateContent(pagedDoc, PageOptions{Section: "환불"}); !errors.Is(err, ErrSectionNotFound) {
+			t.Errorf("Expected ErrSectionNotFound, got %v", err)
+		}
+	})
+
+	t.Run("offset inside a code block starts at the code block", func(t *testing.T) {
+		inside := len([]rune(pagedDoc[:strings.Index(pagedDoc, "npm ls")]))
+		page, err := PaginateContent(pagedDoc, PageOptions{Offset: inside, MaxChars: 1000})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.HasPrefix(page.Content, "```bash") {
+			t.Errorf("Expected page to start at the code fence, got %q", page.Content)
+		}
+	})
+
+	t.Run("oversized paragraph is cut", func(t *testing.T) {
+		long := strings.Repeat("가", 100)
+		page, err := PaginateContent(long, PageOptions{MaxChars: 30})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if page.End != 30 || !page.HasMore {
+			t.Errorf("Expected a 30 character page, got %+v", page)
+		}
+	})
+}
+
+func TestEstimateTokens(t *testing.T) {
+	if got := EstimateTokens("abcdefgh"); got != 2 {
+		t.Errorf("Expected 2 tokens for 8 ASCII characters, got %d", got)
+	}
+	if got := EstimateTokens("결제"); got != 2 {
+		t.Errorf("Expected 2 tokens for 2 Hangul syllables, got %d", got)
+	}
+}
+
+func TestApplyTokenBudget(t *testing.T) {
+	first := strings.Repeat("abcd", 10) // 10 tokens
+	second := "# 제목\n\n" + strings.Repeat("가", 30) + "\n"
+	third := "마지막 문서"
+
+	pages := ApplyTokenBudget([]string{first, second, third}, 25)
+
+	if pages[0].Content != first || pages[0].HasMore {
+		t.Errorf("Expected the first document in full, got %+v", pages[0])
+	}
+	if !pages[1].HasMore || pages[1].EstimatedTokens > 15 || pages[1].Content == "" {
+		t.Errorf("Expected the second document cut to the remaining 15 tokens, got %+v", pages[1])
+	}
+	if pages[2].Content != "" || !pages[2].HasMore || pages[2].TotalTokens != EstimateTokens(third) {
+		t.Errorf("Expected the third document to be left out, got %+v", pages[2])
+	}
+
+	unlimited := ApplyTokenBudget([]string{first, second, third}, 0)
+	for i, page := range unlimited {
+		if page.HasMore {
+			t.Errorf("Expected document %d in full without a budget, got %+v", i, page)
+		}
+	}
+}
@@ -0,0 +1,224 @@
+package search
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// 기본 제공 부스트 프로필 이름입니다
+const (
+	ProfileComponent    = "component"
+	ProfileErrorMessage = "error_message"
+	ProfileAPIReference = "api_reference"
+	ProfileGuide        = "guide"
+
+	// ProfileAuto는 쿼리 형태를 보고 프로필을 고릅니다 (ClassifyQuery)
+	ProfileAuto = "auto"
+)
+
+// profilesFileName은 사용자 설정 디렉터리(os.UserConfigDir()/ax)에서 프로필을 추가하거나 덮어쓰는 파일입니다.
+//
+//	{"profiles": {"component": {"title": 10}, "my_profile": {"content": 4, "category": 0}}}
+const profilesFileName = "search-profiles.json"
+
+func floatRef(v float64) *float64 {
+	return &v
+}
+
+// builtinProfiles는 자주 쓰는 쿼리 의도별 부스트입니다. 지정하지 않은 필드는 기본값을 사용합니다.
+var builtinProfiles = map[string]BoostOverrides{
+	// 컴포넌트 이름(Button, TextField)은 대부분 문서 제목과 일치합니다
+	ProfileComponent: {Title: floatRef(8.0), Description: floatRef(2.0), Content: floatRef(0.5)},
+	// 에러 문자열은 제목이 아니라 본문(에러 코드 표, 트러블슈팅)에 나옵니다
+	ProfileErrorMessage: {Title: floatRef(1.0), Description: floatRef(1.0), Content: floatRef(3.0), Category: floatRef(0)},
+	// API 이름은 레퍼런스 문서 제목과 시그니처가 있는 본문에 함께 나옵니다
+	ProfileAPIReference: {Title: floatRef(6.0), Content: floatRef(2.0), Category: floatRef(0.5)},
+	// 개념이나 절차를 묻는 쿼리는 설명과 카테고리가 중요하고, 많이 참조되는 가이드가 유리합니다
+	ProfileGuide: {Title: floatRef(3.0), Description: floatRef(2.0), Content: floatRef(1.5), Category: floatRef(1.5), Links: floatRef(0.3)},
+}
+
+var (
+	userProfilesOnce sync.Once
+	userProfiles     map[string]BoostOverrides
+	userProfilesErr  error
+
+	// userProfilesPath는 테스트에서 바꿀 수 있도록 변수로 둡니다
+	userProfilesPath = defaultUserProfilesPath
+)
+
+func defaultUserProfilesPath() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, cacheSubDir, profilesFileName), nil
+}
+
+// loadUserProfiles는 사용자 프로필 파일을 한 번만 읽습니다. 파일이 없으면 빈 목록입니다.
+func loadUserProfiles() (map[string]BoostOverrides, error) {
+	userProfilesOnce.Do(func() {
+		path, err := userProfilesPath()
+		if err != nil {
+			return
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			if !os.IsNotExist(err) {
+				userProfilesErr = fmt.Errorf("failed to read search profiles: %w", err)
+			}
+			return
+		}
+
+		var file struct {
+			Profiles map[string]BoostOverrides `json:"profiles"`
+		}
+		if err := json.Unmarshal(data, &file); err != nil {
+			userProfilesErr = fmt.Errorf("failed to parse search profiles %s: %w", path, err)
+			return
+		}
+		userProfiles = file.Profiles
+	})
+	return userProfiles, userProfilesErr
+}
+
+// ProfileNames는 사용할 수 있는 프로필 이름을 정렬해 반환합니다 (auto 제외)
+func ProfileNames() []string {
+	names := make([]string, 0, len(builtinProfiles))
+	for name := range builtinProfiles {
+		names = append(names, name)
+	}
+	user, _ := loadUserProfiles()
+	for name := range user {
+		if _, ok := builtinProfiles[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// lookupProfile은 이름에 해당하는 부스트를 반환합니다.
+// 사용자 프로필은 같은 이름의 기본 프로필 위에 필드 단위로 덮어씁니다.
+func lookupProfile(name string) (BoostOverrides, error) {
+	user, err := loadUserProfiles()
+	if err != nil {
+		return BoostOverrides{}, err
+	}
+
+	builtin, isBuiltin := builtinProfiles[name]
+	custom, isCustom := user[name]
+	if !isBuiltin && !isCustom {
+		return BoostOverrides{}, InvalidArgument(fmt.Errorf("unknown search profile %q (available: %s, %s)", name, strings.Join(ProfileNames(), ", "), ProfileAuto))
+	}
+	return builtin.merge(custom), nil
+}
+
+// ResolveProfile은 SearchOptions.Profile 값을 실제로 적용할 프로필 이름으로 바꿉니다.
+// auto이면 query를 분류하며, 어느 프로필에도 해당하지 않으면 빈 문자열(기본 부스트)을 반환합니다.
+func ResolveProfile(name, query string) (string, error) {
+	name = strings.TrimSpace(name)
+	switch name {
+	case "":
+		return "", nil
+	case ProfileAuto:
+		return ClassifyQuery(query), nil
+	}
+	if _, err := lookupProfile(name); err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
+var (
+	// INVALID_PARAMETER, ERR_NETWORK 같은 에러 코드
+	errorCodePattern = regexp.MustCompile(`\b[A-Z][A-Z0-9]*_[A-Z0-9_]+\b`)
+	// TypeError, NetworkException 같은 에러 타입 이름
+	errorTypePattern = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*(Error|Exception)$`)
+	// "Error: ...", 404 Not Found, "failed to ..." 같은 에러 문구. setTimeout, onError, isInvalid처럼
+	// 식별자 안에 들어 있는 단어는 에러로 보지 않도록 단어 경계로 찾습니다.
+	errorWordPattern = regexp.MustCompile(`(?i)\b(error|exception|failed|failure|cannot|can't|unable|undefined|not found|denied|invalid|timeout)\b`)
+	// HTTP 500, 404 같은 상태 코드. \b는 한글을 단어 문자로 보지 않아 "500원", "400개"에도 맞으므로
+	// 앞뒤에 어떤 문자나 숫자도 붙지 않은 세 자리 숫자만 찾습니다.
+	statusCodePattern = regexp.MustCompile(`(?:^|[^\pL\pN_])[45]\d\d(?:$|[^\pL\pN_])`)
+	// appLogin, getUserKeyForGame, useNavigation() 같은 식별자
+	camelCasePattern = regexp.MustCompile(`^[a-z]+[A-Z][A-Za-z0-9]*(\(\))?$`)
+	// Button, TextField 같은 컴포넌트 이름
+	pascalCasePattern = regexp.MustCompile(`^[A-Z][a-z0-9]+([A-Z][a-z0-9]+)*$`)
+	// 절차나 개념을 묻는 한국어 표현
+	guideWords = []string{"방법", "가이드", "연동", "시작", "설정", "하는 법", "어떻게", "튜토리얼", "개요", "소개", "정책"}
+)
+
+// ClassifyQuery는 쿼리 형태로 알맞은 프로필을 추정합니다. 판단할 수 없으면 빈 문자열을 반환합니다.
+func ClassifyQuery(query string) string {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return ""
+	}
+
+	words := strings.Fields(query)
+	if strings.Contains(query, "에러") || strings.Contains(query, "오류") ||
+		errorCodePattern.MatchString(query) || (len(words) == 1 && errorTypePattern.MatchString(words[0])) {
+		return ProfileErrorMessage
+	}
+
+	// 한 단어 식별자는 에러 문구보다 먼저 판단합니다 (ErrorBoundary는 컴포넌트, onError는 API)
+	if len(words) == 1 {
+		word := strings.TrimSuffix(words[0], "()")
+		switch {
+		case camelCasePattern.MatchString(words[0]) || strings.Contains(word, "."):
+			return ProfileAPIReference
+		case pascalCasePattern.MatchString(word):
+			return ProfileComponent
+		}
+	}
+
+	if errorWordPattern.MatchString(query) || statusCodePattern.MatchString(query) {
+		return ProfileErrorMessage
+	}
+
+	for _, w := range guideWords {
+		if strings.Contains(query, w) {
+			return ProfileGuide
+		}
+	}
+	if hasHangul(query) && len(words) > 1 {
+		return ProfileGuide
+	}
+	return ""
+}
+
+func hasHangul(s string) bool {
+	for _, r := range s {
+		if unicode.Is(unicode.Hangul, r) {
+			return true
+		}
+	}
+	return false
+}
+
+// merge는 b 위에 other에서 지정한 필드를 덮어쓴 결과를 반환합니다
+func (b BoostOverrides) merge(other BoostOverrides) BoostOverrides {
+	if other.Title != nil {
+		b.Title = other.Title
+	}
+	if other.Description != nil {
+		b.Description = other.Description
+	}
+	if other.Content != nil {
+		b.Content = other.Content
+	}
+	if other.Category != nil {
+		b.Category = other.Category
+	}
+	if other.Links != nil {
+		b.Links = other.Links
+	}
+	return b
+}
@@ -0,0 +1,175 @@
+package search
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+// useUserProfiles는 테스트 동안 사용자 프로필 파일 경로를 바꾸고 캐시를 초기화합니다
+func useUserProfiles(t *testing.T, content string) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), profilesFileName)
+	if content != "" {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write profiles: %v", err)
+		}
+	}
+
+	reset := func() {
+		userProfilesOnce = sync.Once{}
+		userProfiles = nil
+		userProfilesErr = nil
+	}
+	reset()
+	userProfilesPath = func() (string, error) { return path, nil }
+	t.Cleanup(func() {
+		reset()
+		userProfilesPath = defaultUserProfilesPath
+	})
+}
+
+func TestClassifyQuery(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"Button", ProfileComponent},
+		{"TextField", ProfileComponent},
+		{"appLogin", ProfileAPIReference},
+		{"getUserKeyForGame()", ProfileAPIReference},
+		{"navigator.share", ProfileAPIReference},
+		{"INVALID_PARAMETER", ProfileErrorMessage},
+		{"TypeError: Cannot read properties of undefined", ProfileErrorMessage},
+		{"결제 승인 에러", ProfileErrorMessage},
+		{"TypeError", ProfileErrorMessage},
+		{"404 Not Found", ProfileErrorMessage},
+		{"HTTP 500", ProfileErrorMessage},
+		{"결제 500원 할인", ProfileGuide},
+		{"400개", ""},
+		{"setTimeout", ProfileAPIReference},
+		{"onError", ProfileAPIReference},
+		{"isInvalid", ProfileAPIReference},
+		{"ErrorBoundary", ProfileComponent},
+		{"결제 연동 방법", ProfileGuide},
+		{"토스 로그인 흐름", ProfileGuide},
+		{"결제", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := ClassifyQuery(tt.query); got != tt.want {
+			t.Errorf("ClassifyQuery(%q) = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestResolveProfile(t *testing.T) {
+	useUserProfiles(t, `{"profiles": {"unity": {"category": 5}}}`)
+
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{"", "Button", ""},
+		{ProfileGuide, "Button", ProfileGuide},
+		{ProfileAuto, "Button", ProfileComponent},
+		{"unity", "결제", "unity"},
+	}
+	for _, tt := range tests {
+		got, err := ResolveProfile(tt.name, tt.query)
+		if err != nil {
+			t.Fatalf("ResolveProfile(%q, %q) failed: %v", tt.name, tt.query, err)
+		}
+		if got != tt.want {
+			t.Errorf("ResolveProfile(%q, %q) = %q, want %q", tt.name, tt.query, got, tt.want)
+		}
+	}
+
+	if _, err := ResolveProfile("unknown", "결제"); err == nil {
+		t.Error("Expected error for unknown profile")
+	}
+}
+
+func TestLookupProfile_UserOverridesBuiltin(t *testing.T) {
+	useUserProfiles(t, `{"profiles": {"component": {"title": 20}}}`)
+
+	boosts, err := lookupProfile(ProfileComponent)
+	if err != nil {
+		t.Fatalf("lookupProfile failed: %v", err)
+	}
+	resolved := boosts.resolve()
+	if resolved.Title != 20 {
+		t.Errorf("Expected user title boost 20, got %v", resolved.Title)
+	}
+	// 사용자 파일에 없는 필드는 기본 프로필 값을 유지해야 함
+	if resolved.Content != *builtinProfiles[ProfileComponent].Content {
+		t.Errorf("Expected builtin content boost, got %v", resolved.Content)
+	}
+}
+
+func TestLookupProfile_InvalidUserFile(t *testing.T) {
+	useUserProfiles(t, `{"profiles": `)
+
+	if _, err := lookupProfile(ProfileGuide); err == nil {
+		t.Error("Expected error for malformed profiles file")
+	}
+}
+
+func TestSearcher_SearchWithProfile(t *testing.T) {
+	useUserProfiles(t, "")
+
+	s, tempDir := testSearcher(t, appsInTossIndexer, nil)
+	defer os.RemoveAll(tempDir)
+
+	if err := s.indexManager.CreateIndex(); err != nil {
+		t.Fatalf("Failed to create index: %v", err)
+	}
+	defer s.Close()
+
+	docs := []IndexDocument{
+		{ID: "timeout-guide", Title: "TIMEOUT 처리", Content: "네트워크 요청이 지연될 때의 처리 방법입니다."},
+		{ID: "error-codes", Title: "에러 코드", Content: "TIMEOUT TIMEOUT 에러가 발생하면 요청을 다시 보내세요. TIMEOUT 코드는 재시도할 수 있습니다."},
+	}
+	if err := s.indexManager.IndexDocuments(docs); err != nil {
+		t.Fatalf("Failed to index: %v", err)
+	}
+
+	ctx := context.Background()
+
+	results, err := s.Search(ctx, "TIMEOUT", nil)
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(results) == 0 || results[0].ID != "timeout-guide" {
+		t.Fatalf("Expected title match first with default boosts, got %+v", results)
+	}
+
+	results, err = s.Search(ctx, "TIMEOUT", &SearchOptions{Profile: ProfileErrorMessage})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(results) == 0 || results[0].ID != "error-codes" {
+		t.Errorf("Expected content match first with error_message profile, got %+v", results)
+	}
+
+	// 명시적인 부스트는 프로필보다 우선해야 함
+	results, err = s.Search(ctx, "TIMEOUT", &SearchOptions{
+		Profile: ProfileErrorMessage,
+		Boosts:  BoostOverrides{Title: floatPtr(50)},
+	})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(results) == 0 || results[0].ID != "timeout-guide" {
+		t.Errorf("Expected explicit title boost to override profile, got %+v", results)
+	}
+
+	if _, err := s.Search(ctx, "TIMEOUT", &SearchOptions{Profile: "unknown"}); err == nil {
+		t.Error("Expected error for unknown profile")
+	}
+}
@@ -0,0 +1,43 @@
+package search
+
+import "fmt"
+
+// ProgressStage는 EnsureIndex가 인덱스를 준비하는 단계입니다
+type ProgressStage string
+
+const (
+	// StageChecking은 원본이 바뀌었는지 네트워크로 확인하는 단계입니다
+	StageChecking ProgressStage = "checking"
+	// StageFetching은 원본을 내려받는 단계입니다
+	StageFetching ProgressStage = "fetching"
+	// StageParsing은 원본을 문서 단위로 나누는 단계입니다
+	StageParsing ProgressStage = "parsing"
+	// StageIndexing은 문서를 색인하는 단계입니다. Current와 Total로 진행률을 알립니다.
+	StageIndexing ProgressStage = "indexing"
+	// StageDone은 새 인덱스를 만들어 교체까지 마친 단계입니다
+	StageDone ProgressStage = "done"
+)
+
+// indexBatchSize는 색인 진행률을 알리는 단위(문서 수)입니다
+const indexBatchSize = 100
+
+// Progress는 인덱스 준비 중에 EnsureIndex가 알리는 진행 상황입니다
+type Progress struct {
+	Stage ProgressStage
+	// Message는 사람이 읽을 수 있는 한 줄 설명입니다
+	Message string
+	// Current와 Total은 StageIndexing에서 색인한 문서 수와 전체 문서 수입니다. 다른 단계에서는 0입니다.
+	Current int
+	Total   int
+}
+
+// ProgressFunc는 EnsureIndex가 진행 상황을 알릴 때 호출하는 함수입니다. nil이면 알리지 않습니다.
+// 인덱스를 새로 만들지 않고 기존 인덱스를 그대로 사용하는 경우에는 호출되지 않을 수 있습니다.
+type ProgressFunc func(Progress)
+
+func (f ProgressFunc) report(stage ProgressStage, current, total int, format string, args ...any) {
+	if f == nil {
+		return
+	}
+	f(Progress{Stage: stage, Message: fmt.Sprintf(format, args...), Current: current, Total: total})
+}
@@ -0,0 +1,263 @@
+package search
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/search/query"
+)
+
+// 검색 문법(SearchOptions.Syntax)에서 사용할 수 있는 필드입니다
+var queryFields = []string{"title", "description", "content", "category", "url"}
+
+// QuerySyntaxError는 검색 문법으로 해석할 수 없는 쿼리에 대한 에러입니다
+type QuerySyntaxError struct {
+	Query    string
+	Position int // 문제가 된 위치 (룬 단위, 0부터 시작)
+	Message  string
+}
+
+func (e *QuerySyntaxError) Error() string {
+	return fmt.Sprintf("invalid query syntax at position %d: %s (query: %q)", e.Position, e.Message, e.Query)
+}
+
+// queryClause는 검색 문법의 단어 또는 구문 하나입니다
+type queryClause struct {
+	field  string // 비어 있으면 모든 필드
+	text   string
+	phrase bool
+}
+
+// parsedQuery는 검색 문법을 해석한 결과입니다.
+// groups는 AND로 결합되고, 각 group 안의 clause는 OR로 결합됩니다.
+type parsedQuery struct {
+	groups   [][]queryClause
+	excluded []queryClause
+}
+
+// parseQuery는 검색 문법을 해석합니다.
+//
+//	결제 연동          두 단어를 모두 포함 (AND)
+//	"결제 연동"        구문 일치
+//	+appLogin          반드시 포함 (기본과 동일)
+//	-unity             제외
+//	title:결제         필드 지정 (title, description, content, category, url)
+//	Button OR Toast    둘 중 하나
+func parseQuery(input string) (*parsedQuery, error) {
+	runes := []rune(input)
+	parsed := &parsedQuery{}
+	pos := 0
+	pendingOr := false
+	orPos := 0
+
+	fail := func(at int, format string, args ...any) error {
+		return &QuerySyntaxError{Query: input, Position: at, Message: fmt.Sprintf(format, args...)}
+	}
+
+	for {
+		for pos < len(runes) && unicode.IsSpace(runes[pos]) {
+			pos++
+		}
+		if pos >= len(runes) {
+			break
+		}
+		start := pos
+
+		// OR 연산자는 따옴표 없이 대문자로 쓴 경우에만 인식합니다
+		if word, next := readWord(runes, pos); word == "OR" {
+			if len(parsed.groups) == 0 || pendingOr {
+				return nil, fail(start, "OR must appear between two terms")
+			}
+			pendingOr = true
+			orPos = start
+			pos = next
+			continue
+		}
+
+		excluded := false
+		switch runes[pos] {
+		case '-':
+			excluded = true
+			pos++
+		case '+':
+			pos++
+		}
+
+		clause := queryClause{}
+		if field, next, ok := readField(runes, pos); ok {
+			if !isQueryField(field) {
+				return nil, fail(pos, "unknown field %q (supported: %s)", field, strings.Join(queryFields, ", "))
+			}
+			clause.field = field
+			pos = next
+		}
+
+		if pos >= len(runes) || unicode.IsSpace(runes[pos]) {
+			return nil, fail(pos, "missing search term")
+		}
+
+		if runes[pos] == '"' {
+			end := pos + 1
+			for end < len(runes) && runes[end] != '"' {
+				end++
+			}
+			if end >= len(runes) {
+				return nil, fail(pos, "unterminated quoted phrase")
+			}
+			clause.text = strings.TrimSpace(string(runes[pos+1 : end]))
+			clause.phrase = true
+			if clause.text == "" {
+				return nil, fail(pos, "empty quoted phrase")
+			}
+			pos = end + 1
+		} else {
+			clause.text, pos = readWord(runes, pos)
+		}
+
+		switch {
+		case excluded && pendingOr:
+			return nil, fail(start, "excluded terms cannot be combined with OR")
+		case excluded:
+			parsed.excluded = append(parsed.excluded, clause)
+		case pendingOr:
+			last := len(parsed.groups) - 1
+			parsed.groups[last] = append(parsed.groups[last], clause)
+			pendingOr = false
+		default:
+			parsed.groups = append(parsed.groups, []queryClause{clause})
+		}
+	}
+
+	if pendingOr {
+		return nil, fail(orPos, "OR must appear between two terms")
+	}
+	if len(parsed.groups) == 0 {
+		return nil, fail(0, "query must include at least one term that is not excluded")
+	}
+
+	return parsed, nil
+}
+
+// readWord는 pos부터 공백 전까지의 단어를 읽습니다
+func readWord(runes []rune, pos int) (string, int) {
+	end := pos
+	for end < len(runes) && !unicode.IsSpace(runes[end]) {
+		end++
+	}
+	return string(runes[pos:end]), end
+}
+
+// readField는 pos부터 "field:" 형식의 필드 지정을 읽습니다
+func readField(runes []rune, pos int) (string, int, bool) {
+	end := pos
+	for end < len(runes) && (unicode.IsLetter(runes[end]) || runes[end] == '_') {
+		end++
+	}
+	if end == pos || end >= len(runes) || runes[end] != ':' {
+		return "", pos, false
+	}
+	return strings.ToLower(string(runes[pos:end])), end + 1, true
+}
+
+func isQueryField(field string) bool {
+	for _, f := range queryFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// toBleveQuery는 해석된 쿼리를 필드 부스트를 적용한 bleve 쿼리로 변환합니다
+func (p *parsedQuery) toBleveQuery(boosts FieldBoosts) query.Query {
+	boolQuery := bleve.NewBooleanQuery()
+
+	for _, group := range p.groups {
+		if len(group) == 1 {
+			boolQuery.AddMust(group[0].toBleveQuery(boosts))
+			continue
+		}
+		alternatives := make([]query.Query, len(group))
+		for i, clause := range group {
+			alternatives[i] = clause.toBleveQuery(boosts)
+		}
+		boolQuery.AddMust(bleve.NewDisjunctionQuery(alternatives...))
+	}
+
+	for _, clause := range p.excluded {
+		boolQuery.AddMustNot(clause.toBleveQuery(boosts))
+	}
+
+	return boolQuery
+}
+
+func (c queryClause) toBleveQuery(boosts FieldBoosts) query.Query {
+	switch {
+	case c.field == "url":
+		// url은 keyword 필드이므로 부분 문자열 일치로 검색합니다
+		wildcard := bleve.NewWildcardQuery("*" + escapeWildcard(c.text) + "*")
+		wildcard.SetField("url")
+		return wildcard
+	case c.field != "":
+		// 필드를 지정한 조건은 해당 필드 부스트가 0이어도 결과를 걸러야 하므로 기본 가중치로 매칭합니다.
+		// 부스트 0인 쿼리만 남으면 bleve의 쿼리 정규화가 0으로 나눠져 점수가 NaN이 됩니다.
+		boost := boosts.forField(c.field)
+		if boost == 0 {
+			boost = 1.0
+		}
+		return c.fieldQuery(c.field, boost)
+	case c.phrase:
+		return bleve.NewDisjunctionQuery(
+			c.fieldQuery("title", boosts.Title),
+			c.fieldQuery("description", boosts.Description),
+			c.fieldQuery("content", boosts.Content),
+			c.fieldQuery("category", boosts.Category),
+		)
+	default:
+		return matchAllFields(c.text, boosts)
+	}
+}
+
+// fieldQuery는 한 필드에 대한 단어 또는 구문 쿼리를 만듭니다
+func (c queryClause) fieldQuery(field string, boost float64) query.Query {
+	if c.phrase {
+		phraseQuery := bleve.NewMatchPhraseQuery(c.text)
+		phraseQuery.SetField(field)
+		phraseQuery.Analyzer = "cjk_search"
+		phraseQuery.SetBoost(boost)
+		return phraseQuery
+	}
+
+	matchQuery := bleve.NewMatchQuery(c.text)
+	matchQuery.SetField(field)
+	matchQuery.Analyzer = "cjk_search"
+	matchQuery.SetBoost(boost)
+	if field == "description" || field == "content" {
+		matchQuery.SetAutoFuzziness(true)
+		matchQuery.SetPrefix(1)
+	}
+	return matchQuery
+}
+
+// forField는 필드 이름에 해당하는 부스트를 반환합니다
+func (fb FieldBoosts) forField(field string) float64 {
+	switch field {
+	case "title":
+		return fb.Title
+	case "description":
+		return fb.Description
+	case "content":
+		return fb.Content
+	case "category":
+		return fb.Category
+	default:
+		return 1.0
+	}
+}
+
+func escapeWildcard(text string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `*`, `\*`, `?`, `\?`)
+	return replacer.Replace(text)
+}
@@ -0,0 +1,163 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestParseQuery(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		groups   [][]queryClause
+		excluded []queryClause
+	}{
+		{
+			name:   "plain terms are combined with AND",
+			input:  "결제 연동",
+			groups: [][]queryClause{{{text: "결제"}}, {{text: "연동"}}},
+		},
+		{
+			name:  "phrase, exclusion and field",
+			input: `"appLogin" -unity title:결제`,
+			groups: [][]queryClause{
+				{{text: "appLogin", phrase: true}},
+				{{field: "title", text: "결제"}},
+			},
+			excluded: []queryClause{{text: "unity"}},
+		},
+		{
+			name:   "OR groups alternatives",
+			input:  "Button OR Toast +category:Components",
+			groups: [][]queryClause{{{text: "Button"}, {text: "Toast"}}, {{field: "category", text: "Components"}}},
+		},
+		{
+			name:   "quoted phrase with field",
+			input:  `content:"결제 연동"`,
+			groups: [][]queryClause{{{field: "content", text: "결제 연동", phrase: true}}},
+		},
+		{
+			name:   "url value may contain colons",
+			input:  "url:https://example.com/pay",
+			groups: [][]queryClause{{{field: "url", text: "https://example.com/pay"}}},
+		},
+		{
+			name:   "lowercase or is a plain term",
+			input:  "a or b",
+			groups: [][]queryClause{{{text: "a"}}, {{text: "or"}}, {{text: "b"}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := parseQuery(tt.input)
+			if err != nil {
+				t.Fatalf("parseQuery(%q) failed: %v", tt.input, err)
+			}
+			if !reflect.DeepEqual(parsed.groups, tt.groups) {
+				t.Errorf("groups = %+v, want %+v", parsed.groups, tt.groups)
+			}
+			if !reflect.DeepEqual(parsed.excluded, tt.excluded) {
+				t.Errorf("excluded = %+v, want %+v", parsed.excluded, tt.excluded)
+			}
+		})
+	}
+}
+
+func TestParseQuery_Errors(t *testing.T) {
+	tests := []struct {
+		input    string
+		position int
+	}{
+		{`"결제 연동`, 0},
+		{`""`, 0},
+		{"author:kim", 0},
+		{"title: 결제", 6},
+		{"OR 결제", 0},
+		{"결제 OR", 3},
+		{"결제 OR OR 연동", 6},
+		{"결제 OR -unity", 6},
+		{"-unity", 0},
+		{"", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			_, err := parseQuery(tt.input)
+			var syntaxErr *QuerySyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("Expected QuerySyntaxError, got %v", err)
+			}
+			if syntaxErr.Position != tt.position {
+				t.Errorf("Expected position %d, got %d (%v)", tt.position, syntaxErr.Position, err)
+			}
+		})
+	}
+}
+
+func TestSearcher_SearchWithSyntax(t *testing.T) {
+	s, tempDir := testSearcher(t, appsInTossIndexer, nil)
+	defer os.RemoveAll(tempDir)
+
+	if err := s.indexManager.CreateIndex(); err != nil {
+		t.Fatalf("Failed to create index: %v", err)
+	}
+	defer s.Close()
+
+	docs := []IndexDocument{
+		{ID: "login", Title: "토스 로그인", Content: "appLogin 함수로 로그인합니다.", URL: "https://example.com/login", Category: "인증"},
+		{ID: "unity-login", Title: "Unity 로그인", Content: "Unity에서 appLogin을 호출합니다.", URL: "https://example.com/unity/login", Category: "Unity"},
+		{ID: "payment", Title: "결제 연동", Content: "토스페이 결제를 연동합니다.", URL: "https://example.com/payment", Category: "결제"},
+		{ID: "refund", Title: "환불", Content: "결제 취소와 환불 방법입니다.", URL: "https://example.com/refund", Category: "결제"},
+	}
+	if err := s.indexManager.IndexDocuments(docs); err != nil {
+		t.Fatalf("Failed to index: %v", err)
+	}
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{`"appLogin" -unity`, []string{"login"}},
+		{`title:결제`, []string{"payment"}},
+		{`category:결제 -title:결제`, []string{"refund"}},
+		{`url:unity`, []string{"unity-login"}},
+		{`환불 OR 토스페이`, []string{"payment", "refund"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			results, err := s.Search(context.Background(), tt.query, &SearchOptions{Syntax: true})
+			if err != nil {
+				t.Fatalf("Search failed: %v", err)
+			}
+			got := map[string]bool{}
+			for _, r := range results {
+				got[r.ID] = true
+			}
+			if len(got) != len(tt.want) {
+				t.Errorf("Expected %v, got %+v", tt.want, results)
+			}
+			for _, id := range tt.want {
+				if !got[id] {
+					t.Errorf("Expected %s in results, got %+v", id, results)
+				}
+			}
+		})
+	}
+
+	// 문법 오류는 QuerySyntaxError로 전달되어야 함
+	_, err := s.Search(context.Background(), `"appLogin`, &SearchOptions{Syntax: true})
+	var syntaxErr *QuerySyntaxError
+	if !errors.As(err, &syntaxErr) {
+		t.Errorf("Expected QuerySyntaxError, got %v", err)
+	}
+
+	// 문법을 끄면 따옴표도 일반 텍스트로 검색되어야 함
+	if _, err := s.Search(context.Background(), `"appLogin`, nil); err != nil {
+		t.Errorf("Expected plain search to accept unbalanced quotes, got %v", err)
+	}
+}
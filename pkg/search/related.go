@@ -0,0 +1,172 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"unicode/utf8"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/search/query"
+	index "github.com/blevesearch/bleve_index_api"
+)
+
+const (
+	// 관련 문서 검색에 사용할 대표 단어 수입니다
+	relatedMaxTerms = 25
+
+	// 대표 단어로 쓰기에 너무 짧은 단어(룬 단위)는 제외합니다
+	relatedMinTermLength = 2
+
+	// 인덱싱 analyzer의 edgengram 최대 길이입니다. 이보다 긴 단어는 인덱스에 잘린 형태로만 저장됩니다.
+	indexedTermMaxLength = 10
+
+	// 같은 카테고리 문서에 주는 가중치 (가장 강한 대표 단어 대비 비율)
+	relatedCategoryWeight = 1.0
+)
+
+// ErrDocumentNotFound는 요청한 ID의 문서가 인덱스에 없을 때 반환됩니다
+var ErrDocumentNotFound = errors.New("document not found")
+
+// weightedTerm은 문서를 대표하는 단어와 tf-idf 가중치입니다
+type weightedTerm struct {
+	term   string
+	weight float64
+}
+
+// RelatedDocuments는 id 문서와 비슷한 문서를 찾습니다.
+// 원본 문서의 제목과 본문에서 tf-idf가 높은 단어를 골라 title/content에 매칭하고,
+// 같은 카테고리의 문서에 가중치를 더합니다. 원본 문서는 결과에서 제외됩니다.
+func (im *IndexManager) RelatedDocuments(id string, limit int) ([]IndexDocument, []float64, error) {
+	source, err := im.GetByID(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if source == nil {
+		return nil, nil, fmt.Errorf("%w: %s", ErrDocumentNotFound, id)
+	}
+
+	terms, err := im.representativeTerms(source.Title+"\n"+source.Content, relatedMaxTerms)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var similar []query.Query
+	for _, t := range terms {
+		similar = append(similar, termMatch("title", t.term, t.weight), termMatch("content", t.term, t.weight))
+	}
+	if source.Category != "" {
+		weight := relatedCategoryWeight
+		if len(terms) > 0 {
+			weight *= terms[0].weight
+		}
+		categoryQuery := bleve.NewMatchPhraseQuery(source.Category)
+		categoryQuery.SetField("category")
+		categoryQuery.Analyzer = "cjk_search"
+		categoryQuery.SetBoost(weight)
+		similar = append(similar, categoryQuery)
+	}
+	if len(similar) == 0 {
+		return nil, nil, nil
+	}
+
+	relatedQuery := bleve.NewBooleanQuery()
+	relatedQuery.AddMust(bleve.NewDisjunctionQuery(similar...))
+	relatedQuery.AddMustNot(bleve.NewDocIDQuery([]string{id}))
+
+	return im.SearchQuery(relatedQuery, limit)
+}
+
+// representativeTerms는 text를 검색용 analyzer로 분석해 tf-idf가 높은 단어를 최대 n개 반환합니다.
+// 문서 빈도(df)는 인덱스의 content 필드에서 읽습니다.
+//
+// 저장된 term vector를 읽지 않고 저장된 원문을 다시 분석합니다. content 필드에도 term vector는 켜져 있지만,
+// bleve(scorch)의 term vector는 단어별 posting에 붙은 위치 정보일 뿐 문서별 단어 목록이 아니어서
+// 한 문서의 단어를 얻으려면 사전 전체를 훑어야 합니다. 또 색인 analyzer가 edgengram으로 접두어를 함께 색인하므로
+// 색인된 단어로 tf를 세면 접두어가 대표 단어로 뽑힙니다. 검색용 analyzer로 다시 분석하면 쿼리와 같은 단어가 나옵니다.
+func (im *IndexManager) representativeTerms(text string, n int) ([]weightedTerm, error) {
+	analyzer := im.index.Mapping().AnalyzerNamed("cjk_search")
+	if analyzer == nil {
+		return nil, fmt.Errorf("analyzer not found: cjk_search")
+	}
+
+	frequencies := map[string]int{}
+	for _, token := range analyzer.Analyze([]byte(text)) {
+		term := string(token.Term)
+		if utf8.RuneCountInString(term) < relatedMinTermLength {
+			continue
+		}
+		frequencies[term]++
+	}
+	if len(frequencies) == 0 {
+		return nil, nil
+	}
+
+	advanced, err := im.index.Advanced()
+	if err != nil {
+		return nil, err
+	}
+	reader, err := advanced.Reader()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	docCount, err := reader.DocCount()
+	if err != nil {
+		return nil, err
+	}
+
+	terms := make([]weightedTerm, 0, len(frequencies))
+	for term, tf := range frequencies {
+		df, err := termDocFrequency(reader, indexedTerm(term), "content")
+		if err != nil {
+			return nil, err
+		}
+		// 인덱스에 없거나 모든 문서에 등장하는 단어는 유사도를 구분하지 못합니다
+		if df == 0 || df >= docCount {
+			continue
+		}
+		idf := math.Log(float64(docCount) / float64(df))
+		terms = append(terms, weightedTerm{term: term, weight: math.Sqrt(float64(tf)) * idf})
+	}
+
+	sort.Slice(terms, func(i, j int) bool {
+		if terms[i].weight != terms[j].weight {
+			return terms[i].weight > terms[j].weight
+		}
+		return terms[i].term < terms[j].term
+	})
+	if len(terms) > n {
+		terms = terms[:n]
+	}
+	return terms, nil
+}
+
+// termDocFrequency는 field에서 term을 포함한 문서 수를 반환합니다
+func termDocFrequency(reader index.IndexReader, term, field string) (uint64, error) {
+	tfr, err := reader.TermFieldReader(context.Background(), []byte(term), field, false, false, false)
+	if err != nil {
+		return 0, err
+	}
+	defer tfr.Close()
+	return tfr.Count(), nil
+}
+
+// indexedTerm은 인덱싱 analyzer의 edgengram이 저장하는 형태로 단어를 자릅니다
+func indexedTerm(term string) string {
+	if utf8.RuneCountInString(term) <= indexedTermMaxLength {
+		return term
+	}
+	return string([]rune(term)[:indexedTermMaxLength])
+}
+
+// termMatch는 한 단어를 field에 매칭하는 쿼리를 만듭니다
+func termMatch(field, term string, boost float64) query.Query {
+	termQuery := bleve.NewTermQuery(indexedTerm(term))
+	termQuery.SetField(field)
+	termQuery.SetBoost(boost)
+	return termQuery
+}
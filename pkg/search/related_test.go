@@ -0,0 +1,71 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+)
+
+func TestSearcher_RelatedDocuments(t *testing.T) {
+	s, tempDir := testSearcher(t, appsInTossIndexer, nil)
+	defer os.RemoveAll(tempDir)
+
+	if err := s.indexManager.CreateIndex(); err != nil {
+		t.Fatalf("Failed to create index: %v", err)
+	}
+	defer s.Close()
+
+	docs := []IndexDocument{
+		{ID: "tosspay", Title: "토스페이 결제 연동", Content: "토스페이 결제를 연동하려면 결제 승인 API를 호출합니다. 결제 승인 후 주문 상태를 갱신하세요.", Category: "결제"},
+		{ID: "refund", Title: "토스페이 환불", Content: "결제 승인된 주문을 환불하려면 환불 API를 호출합니다.", Category: "결제"},
+		{ID: "pay-error", Title: "결제 에러 코드", Content: "결제 승인 API가 실패하면 에러 코드를 확인하세요.", Category: "결제"},
+		{ID: "iap", Title: "인앱 결제", Content: "인앱 상품을 판매하는 방법입니다.", Category: "결제"},
+		{ID: "login", Title: "토스 로그인", Content: "appLogin 함수로 사용자를 인증합니다.", Category: "인증"},
+		{ID: "unity", Title: "Unity 시작하기", Content: "Unity 엔진으로 게임을 만드는 방법입니다.", Category: "Unity"},
+	}
+	if err := s.indexManager.IndexDocuments(docs); err != nil {
+		t.Fatalf("Failed to index: %v", err)
+	}
+
+	results, err := s.RelatedDocuments(context.Background(), "tosspay", 3)
+	if err != nil {
+		t.Fatalf("RelatedDocuments failed: %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("Expected 3 related documents, got %+v", results)
+	}
+
+	got := map[string]bool{}
+	for _, r := range results {
+		if r.ID == "tosspay" {
+			t.Error("Source document should not be returned")
+		}
+		got[r.ID] = true
+	}
+	// 같은 카테고리의 문서가 다른 카테고리 문서보다 먼저 나와야 함
+	for _, id := range []string{"refund", "pay-error", "iap"} {
+		if !got[id] {
+			t.Errorf("Expected %s in related documents, got %+v", id, results)
+		}
+	}
+	// 본문이 더 비슷한 문서가 같은 카테고리의 덜 비슷한 문서보다 앞서야 함
+	if results[2].ID != "iap" {
+		t.Errorf("Expected iap to rank last among payment documents, got %+v", results)
+	}
+}
+
+func TestSearcher_RelatedDocumentsNotFound(t *testing.T) {
+	s, tempDir := testSearcher(t, appsInTossIndexer, nil)
+	defer os.RemoveAll(tempDir)
+
+	if err := s.indexManager.CreateIndex(); err != nil {
+		t.Fatalf("Failed to create index: %v", err)
+	}
+	defer s.Close()
+
+	_, err := s.RelatedDocuments(context.Background(), "missing", 5)
+	if !errors.Is(err, ErrDocumentNotFound) {
+		t.Errorf("Expected ErrDocumentNotFound, got %v", err)
+	}
+}
@@ -2,9 +2,12 @@ package search
 
 import (
 	"context"
+	"errors"
 	"os"
 	"path/filepath"
+	"time"
 
+	"github.com/blevesearch/bleve/v2/search/query"
 	"github.com/toss/apps-in-toss-ax/internal/httputil"
 	"github.com/toss/apps-in-toss-ax/pkg/llms"
 )
@@ -13,28 +16,42 @@ import (
 type ContentIndexer func(content string, categoryMap map[string]string) []IndexDocument
 
 type SearchResult struct {
-	ID          string  `json:"id"`
-	Title       string  `json:"title"`
-	Content     string  `json:"content"`
-	Description string  `json:"description"`
-	URL         string  `json:"url"`
-	Category    string  `json:"category"`
-	Score       float64 `json:"score"`
+	ID          string  `json:"id" jsonschema:"Document ID to pass to the get tools"`
+	Title       string  `json:"title" jsonschema:"Document title"`
+	Content     string  `json:"content" jsonschema:"Markdown content (a truncated preview in search results)"`
+	Description string  `json:"description" jsonschema:"Short summary of the document"`
+	URL         string  `json:"url" jsonschema:"Source page URL for citation"`
+	Category    string  `json:"category" jsonschema:"Category path, levels separated by \" > \""`
+	Score       float64 `json:"score" jsonschema:"Relevance score; higher is more relevant"`
+	// Relaxation은 원래 쿼리로 결과가 없어 완화한 검색으로 찾은 결과일 때 그 방식입니다 (근사 결과)
+	Relaxation string `json:"relaxation,omitempty" jsonschema:"How the query was relaxed when the original query matched nothing (approximate result)"`
+	// Explanation은 SearchOptions.Explain을 켠 경우 필드별 점수 내역입니다
+	Explanation *ScoreExplanation `json:"explanation,omitempty" jsonschema:"Per-field score breakdown, present when explain is true"`
 }
 
 type SearchOptions struct {
 	Limit            int
 	MaxContentLength int
 	Boosts           BoostOverrides
+	// Syntax가 true이면 쿼리를 검색 문법(구문, +/- 단어, 필드 지정, OR)으로 해석합니다
+	Syntax bool
+	// DisableFallback이 true이면 결과가 없을 때 완화한 쿼리로 다시 검색하지 않습니다
+	DisableFallback bool
+	// Profile은 쿼리 의도별 부스트 프로필 이름입니다 (component, error_message, api_reference, guide, auto).
+	// Boosts에 지정한 값은 프로필보다 우선합니다.
+	Profile string
+	// Explain이 true이면 각 결과에 필드별 점수 내역(SearchResult.Explanation)을 채웁니다
+	Explain bool
 }
 
 // BoostOverrides는 필드별 부스트 재정의 값입니다.
 // nil인 필드는 기본값(DefaultFieldBoosts)을 사용합니다.
 type BoostOverrides struct {
-	Title       *float64
-	Description *float64
-	Content     *float64
-	Category    *float64
+	Title       *float64 `json:"title,omitempty"`
+	Description *float64 `json:"description,omitempty"`
+	Content     *float64 `json:"content,omitempty"`
+	Category    *float64 `json:"category,omitempty"`
+	Links       *float64 `json:"links,omitempty"`
 }
 
 // resolve는 재정의 값을 기본값과 병합해 최종 가중치를 계산합니다
@@ -52,6 +69,9 @@ func (b BoostOverrides) resolve() FieldBoosts {
 	if b.Category != nil {
 		boosts.Category = *b.Category
 	}
+	if b.Links != nil {
+		boosts.Links = *b.Links
+	}
 	return boosts
 }
 
@@ -62,6 +82,7 @@ type Searcher struct {
 	indexManager *IndexManager
 	indexer      ContentIndexer
 	urlTransform URLTransformFunc
+	refresh      RefreshPolicy
 }
 
 func newSearcher(llmsFullUrl, llmsUrl string, cacheConfig CacheConfig, indexer ContentIndexer, urlTransform URLTransformFunc) (*Searcher, error) {
@@ -121,76 +142,217 @@ func NewTDSMobileSearcher() (*Searcher, error) {
 	)
 }
 
-func (s *Searcher) EnsureIndex(ctx context.Context) error {
-	indexExists := s.cacheManager.IndexExists()
+// errSchemaOutdated는 캐시된 인덱스가 현재 IndexSchemaVersion과 다른 매핑으로 만들어졌음을 나타냅니다
+var errSchemaOutdated = errors.New("search index schema is outdated")
 
-	if indexExists {
-		etag, changed, err := s.cacheManager.CheckETag(ctx, s.llmsFullUrl)
-		if err != nil {
-			if err := s.indexManager.OpenIndex(); err == nil {
-				return nil
-			}
-			return s.buildIndex(ctx)
+// SetRefreshPolicy는 EnsureIndex가 원본 변경 여부를 확인하는 방식을 설정합니다
+func (s *Searcher) SetRefreshPolicy(policy RefreshPolicy) {
+	s.refresh = policy
+}
+
+// EnsureIndex는 검색 가능한 인덱스를 준비합니다.
+// 인덱스가 없거나 손상되었거나 스키마가 바뀐 경우 로컬에 저장된 원본으로 먼저 다시 만들고,
+// 원본이 없을 때만 네트워크에서 내려받습니다. 실패하면 ErrIndexUnavailable로 분류된 에러를 반환합니다.
+// progress가 nil이 아니면 원본 확인, 내려받기, 파싱, 색인 단계마다 호출합니다.
+func (s *Searcher) EnsureIndex(ctx context.Context, progress ProgressFunc) error {
+	if err := s.ensureIndex(ctx, progress); err != nil {
+		return IndexUnavailable(err)
+	}
+	return nil
+}
+
+func (s *Searcher) ensureIndex(ctx context.Context, progress ProgressFunc) error {
+	if s.refresh.FreshnessTTL > 0 {
+		if err := s.cacheManager.SetFreshnessTTL(s.refresh.FreshnessTTL); err != nil {
+			return err
 		}
+	}
 
-		if !changed {
-			if err := s.indexManager.OpenIndex(); err == nil {
-				return nil
-			}
-			_ = s.cacheManager.DeleteIndex()
-			return s.buildIndex(ctx)
+	if s.cacheManager.IndexExists() {
+		if err := s.openCurrentIndex(); err == nil {
+			return s.refreshIndex(ctx, progress)
 		}
+	}
 
-		if err := s.cacheManager.DeleteIndex(); err != nil {
-			return err
+	if err := s.buildIndexFromCachedSource(progress); err == nil {
+		return s.refreshIndex(ctx, progress)
+	}
+
+	return s.buildIndex(ctx, progress)
+}
+
+// refreshIndex는 원본이 바뀌었으면 인덱스를 새로 만듭니다.
+// 최근에 확인했거나 최근 확인이 네트워크 오류로 실패했다면 네트워크 요청 없이 기존 인덱스를 사용합니다.
+// 새 인덱스는 기존 인덱스를 지우지 않고 교체하므로, 네트워크 오류 등으로 실패하면 기존 인덱스를 계속 사용합니다.
+func (s *Searcher) refreshIndex(ctx context.Context, progress ProgressFunc) error {
+	if !s.refresh.Force && !s.cacheManager.NeedsValidation(time.Now()) {
+		return nil
+	}
+
+	progress.report(StageChecking, 0, 0, "checking %s for updates", s.llmsFullUrl)
+	etag, changed, err := s.cacheManager.CheckETag(ctx, s.llmsFullUrl)
+	if err != nil {
+		s.markCheckFailed(ctx, err)
+		return nil
+	}
+	if !changed {
+		_ = s.cacheManager.MarkValidated()
+		return nil
+	}
+
+	if err := s.buildIndexWithETag(ctx, etag, progress); err != nil {
+		s.markCheckFailed(ctx, err)
+		if s.indexManager.isOpen() {
+			return nil
 		}
+		if openErr := s.indexManager.OpenIndex(); openErr == nil {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+// markCheckFailed는 네트워크 오류로 원본을 확인하지 못했을 때만 실패를 기록해 offlineRetryInterval 동안 확인을 건너뛰게 합니다.
+// 취소된 호출이나 네트워크와 관계없는 에러는 다음 호출에서 바로 다시 확인하도록 기록하지 않습니다.
+func (s *Searcher) markCheckFailed(ctx context.Context, err error) {
+	var fetchErr *httputil.FetchError
+	if ctx.Err() != nil || !errors.As(err, &fetchErr) {
+		return
+	}
+	_ = s.cacheManager.MarkCheckFailed()
+}
 
-		return s.buildIndexWithETag(ctx, etag)
+// openCurrentIndex는 캐시된 인덱스를 열고, 메타데이터와 인덱스에 기록된 스키마 버전이
+// 현재 매핑과 같은지 확인합니다. 매핑이 바뀐 캐시도 bleve로는 문제없이 열리지만
+// 이전 analyzer로 검색되므로 errSchemaOutdated를 반환합니다.
+func (s *Searcher) openCurrentIndex() error {
+	metadata, err := s.cacheManager.LoadMetadata()
+	if err != nil {
+		return err
 	}
 
-	return s.buildIndex(ctx)
+	if err := s.indexManager.OpenIndex(); err != nil {
+		return err
+	}
+
+	version, err := s.indexManager.SchemaVersion()
+	if err != nil || version != IndexSchemaVersion || metadata.SchemaVersion != IndexSchemaVersion {
+		s.indexManager.Close()
+		return errSchemaOutdated
+	}
+
+	return nil
 }
 
-func (s *Searcher) buildIndex(ctx context.Context) error {
-	return s.buildIndexWithETag(ctx, "")
+func (s *Searcher) buildIndex(ctx context.Context, progress ProgressFunc) error {
+	return s.buildIndexWithETag(ctx, "", progress)
 }
 
-func (s *Searcher) buildIndexWithETag(ctx context.Context, etag string) error {
-	content, newETag, err := httputil.FetchWithETag(ctx, s.llmsFullUrl, 0)
+func (s *Searcher) buildIndexWithETag(ctx context.Context, etag string, progress ProgressFunc) error {
+	full, err := s.fetchSource(ctx, s.llmsFullUrl, llmsFullSourceName, progress)
 	if err != nil {
 		return err
 	}
 
-	if newETag != "" {
-		etag = newETag
+	if full.ETag == "" {
+		full.ETag = etag
 	}
 
-	categoryMap := s.fetchCategoryMap(ctx)
+	// llms.txt는 카테고리 정보에만 쓰이므로, 받지 못하면 저장된 원본을 사용하고 그마저 없으면 카테고리 없이 색인합니다
+	index, err := s.fetchSource(ctx, s.llmsUrl, llmsSourceName, progress)
+	if err != nil {
+		index, _ = s.cacheManager.LoadSource(llmsSourceName)
+	}
 
-	if err := s.indexManager.CreateIndex(); err != nil {
+	if err := s.buildIndexFromSources(full, index, progress); err != nil {
 		return err
 	}
 
-	documents := s.indexer(content, categoryMap)
-	if err := s.indexManager.IndexDocuments(documents); err != nil {
+	return s.cacheManager.MarkValidated()
+}
+
+// errNoCachedSource는 로컬에 재색인할 원본이 없음을 나타냅니다
+var errNoCachedSource = errors.New("no cached source to rebuild the index from")
+
+// buildIndexFromCachedSource는 네트워크 없이 로컬에 저장된 원본으로 인덱스를 다시 만듭니다
+func (s *Searcher) buildIndexFromCachedSource(progress ProgressFunc) error {
+	full, err := s.cacheManager.LoadSource(llmsFullSourceName)
+	if err != nil {
 		return err
 	}
+	if full == nil || full.URL != s.llmsFullUrl {
+		return errNoCachedSource
+	}
 
-	if etag != "" {
-		if err := s.cacheManager.SaveETag(s.llmsFullUrl, etag); err != nil {
-			return err
-		}
+	index, _ := s.cacheManager.LoadSource(llmsSourceName)
+	if index != nil && index.URL != s.llmsUrl {
+		index = nil
+	}
+
+	return s.buildIndexFromSources(full, index, progress)
+}
+
+func (s *Searcher) buildIndexFromSources(full, index *RawSource, progress ProgressFunc) error {
+	var categoryMap map[string]string
+	if index != nil {
+		categoryMap = s.parseCategoryMap(index.Content)
 	}
 
+	documents := s.indexer(full.Content, categoryMap)
+	progress.report(StageParsing, 0, 0, "parsed %d documents", len(documents))
+
+	err := s.indexManager.buildIndex(documents, func(indexed int) {
+		progress.report(StageIndexing, indexed, len(documents), "indexed %d/%d documents", indexed, len(documents))
+	})
+	if err != nil {
+		return err
+	}
+
+	// ETag가 없더라도 스키마 버전을 기록하기 위해 메타데이터는 항상 저장합니다
+	if err := s.cacheManager.SaveETag(s.llmsFullUrl, full.ETag); err != nil {
+		return err
+	}
+	progress.report(StageDone, len(documents), len(documents), "index ready: %d documents", len(documents))
 	return nil
 }
 
-func (s *Searcher) fetchCategoryMap(ctx context.Context) map[string]string {
-	content, _, err := httputil.FetchWithETag(ctx, s.llmsUrl, 0)
+// fetchSource는 원본을 내려받아 로컬 원본 캐시에 저장합니다.
+// 저장된 원본의 ETag/Last-Modified로 조건부 요청을 보내, 바뀌지 않았으면 저장된 원본을 그대로 사용합니다.
+func (s *Searcher) fetchSource(ctx context.Context, url, name string, progress ProgressFunc) (*RawSource, error) {
+	cached, _ := s.cacheManager.LoadSource(name)
+
+	var validators httputil.Validators
+	if cached != nil && cached.URL == url {
+		validators = httputil.Validators{
+			ETag:         cached.ETag,
+			LastModified: cached.LastModified,
+		}
+	}
+
+	progress.report(StageFetching, 0, 0, "fetching %s", url)
+	result, err := httputil.Fetch(ctx, url, validators, 0)
 	if err != nil {
-		return nil
+		return nil, err
+	}
+	if result.NotModified && cached != nil {
+		return cached, nil
+	}
+
+	source := &RawSource{
+		URL:          url,
+		ETag:         result.ETag,
+		LastModified: result.LastModified,
+		Content:      result.Content,
 	}
 
+	// 원본 캐시는 다음 재색인을 빠르게 하기 위한 것이므로, 저장에 실패해도 이번 색인은 계속합니다
+	_ = s.cacheManager.SaveSource(name, *source)
+
+	return source, nil
+}
+
+func (s *Searcher) parseCategoryMap(content string) map[string]string {
 	parser := llms.NewParser()
 	llmsTxt, err := parser.Parse(content)
 	if err != nil {
@@ -203,9 +365,39 @@ func (s *Searcher) fetchCategoryMap(ctx context.Context) map[string]string {
 const defaultMaxContentLength = 500
 
 func (s *Searcher) Search(ctx context.Context, query string, opts *SearchOptions) ([]SearchResult, error) {
+	results, _, err := s.search(query, opts)
+	return results, err
+}
+
+// SearchWithFacets는 Search와 같은 결과와 함께, 결과를 낸 쿼리와 매칭되는 모든 문서의 카테고리별 개수를 반환합니다
+func (s *Searcher) SearchWithFacets(ctx context.Context, query string, opts *SearchOptions) ([]SearchResult, []CategoryFacet, error) {
+	results, hits, err := s.search(query, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	facets, err := s.indexManager.categoryFacets(hits.query)
+	if err != nil {
+		return nil, nil, err
+	}
+	return results, facets, nil
+}
+
+// searchHits는 검색 결과와 그 결과를 낸 쿼리입니다 (완화한 검색이면 완화한 쿼리)
+type searchHits struct {
+	query      query.Query
+	docs       []IndexDocument
+	scores     []float64
+	relaxation string
+}
+
+func (s *Searcher) search(text string, opts *SearchOptions) ([]SearchResult, *searchHits, error) {
 	limit := 10
 	maxContentLen := defaultMaxContentLength
 	boosts := DefaultFieldBoosts()
+	syntax := false
+	fallback := true
+	explain := false
 	if opts != nil {
 		if opts.Limit > 0 {
 			limit = opts.Limit
@@ -213,14 +405,81 @@ func (s *Searcher) Search(ctx context.Context, query string, opts *SearchOptions
 		if opts.MaxContentLength > 0 {
 			maxContentLen = opts.MaxContentLength
 		}
-		boosts = opts.Boosts.resolve()
+		syntax = opts.Syntax
+		fallback = !opts.DisableFallback
+		explain = opts.Explain
+
+		var err error
+		if boosts, err = opts.resolveBoosts(text); err != nil {
+			return nil, nil, err
+		}
 	}
 
-	docs, scores, err := s.indexManager.SearchWithBoosts(query, limit, boosts)
+	searchQuery, err := buildSearchQuery(text, syntax, boosts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	hits := &searchHits{query: searchQuery}
+	if hits.docs, hits.scores, err = s.indexManager.searchRanked(searchQuery, limit, boosts.Links, explain); err != nil {
+		return nil, nil, err
+	}
+
+	if len(hits.docs) == 0 && fallback {
+		relaxed, err := s.searchRelaxed(text, syntax, limit, boosts, explain)
+		if err != nil {
+			return nil, nil, err
+		}
+		if relaxed != nil {
+			hits = relaxed
+		}
+	}
+
+	results := toSearchResults(hits.docs, hits.scores, maxContentLen)
+	for i := range results {
+		results[i].Relaxation = hits.relaxation
+	}
+	if explain {
+		if err := s.explainResults(results, hits.docs, text, boosts.Links); err != nil {
+			return nil, nil, err
+		}
+	}
+	return results, hits, nil
+}
+
+// resolveBoosts는 프로필 부스트 위에 Boosts 재정의를 적용한 최종 가중치를 계산합니다
+func (o *SearchOptions) resolveBoosts(query string) (FieldBoosts, error) {
+	profile, err := ResolveProfile(o.Profile, query)
+	if err != nil {
+		return FieldBoosts{}, err
+	}
+
+	var base BoostOverrides
+	if profile != "" {
+		if base, err = lookupProfile(profile); err != nil {
+			return FieldBoosts{}, err
+		}
+	}
+	return base.merge(o.Boosts).resolve(), nil
+}
+
+// RelatedDocuments는 id 문서와 내용이 비슷하거나 같은 카테고리에 속한 문서를 limit개까지 반환합니다.
+// 문서가 없으면 ErrDocumentNotFound를 감싼 에러를 반환합니다.
+func (s *Searcher) RelatedDocuments(ctx context.Context, id string, limit int) ([]SearchResult, error) {
+	if limit <= 0 {
+		limit = 5
+	}
+
+	docs, scores, err := s.indexManager.RelatedDocuments(id, limit)
 	if err != nil {
 		return nil, err
 	}
 
+	return toSearchResults(docs, scores, defaultMaxContentLength), nil
+}
+
+// toSearchResults는 인덱스 검색 결과를 콘텐츠를 잘라낸 SearchResult로 변환합니다
+func toSearchResults(docs []IndexDocument, scores []float64, maxContentLen int) []SearchResult {
 	results := make([]SearchResult, len(docs))
 	for i, doc := range docs {
 		results[i] = SearchResult{
@@ -233,8 +492,24 @@ func (s *Searcher) Search(ctx context.Context, query string, opts *SearchOptions
 			Score:       scores[i],
 		}
 	}
+	return results
+}
 
-	return results, nil
+// buildSearchQuery는 쿼리를 모든 필드에 매칭하는 bleve 쿼리로 만듭니다.
+// syntax가 true이면 검색 문법으로 해석합니다.
+func buildSearchQuery(text string, syntax bool, boosts FieldBoosts) (query.Query, error) {
+	if err := boosts.validate(); err != nil {
+		return nil, err
+	}
+	if !syntax {
+		return matchAllFields(text, boosts), nil
+	}
+
+	parsed, err := parseQuery(text)
+	if err != nil {
+		return nil, err
+	}
+	return parsed.toBleveQuery(boosts), nil
 }
 
 // truncateContent는 콘텐츠를 maxLen 룬 이하로 잘라냅니다.
@@ -269,8 +544,47 @@ func (s *Searcher) Close() error {
 	return s.indexManager.Close()
 }
 
+// errNoCachedIndex는 OpenCachedIndex로 열 이전 인덱스가 디스크에 없음을 나타냅니다
+var errNoCachedIndex = errors.New("no cached search index")
+
+// OpenCachedIndex는 네트워크 확인이나 재색인 없이 디스크에 남아 있는 이전 인덱스를 엽니다.
+// 인덱스가 없거나 스키마가 바뀌었으면 에러를 반환하며, 이때는 EnsureIndex로 인덱스를 만들어야 합니다.
+func (s *Searcher) OpenCachedIndex() error {
+	if !s.cacheManager.IndexExists() {
+		return errNoCachedIndex
+	}
+	return s.openCurrentIndex()
+}
+
+// NeedsRefresh는 EnsureIndex가 원본 변경 여부를 네트워크로 확인할 차례인지 반환합니다
+func (s *Searcher) NeedsRefresh() bool {
+	return s.refresh.Force || s.cacheManager.NeedsValidation(time.Now())
+}
+
+// DocCount는 열린 인덱스의 문서 수입니다. 인덱스가 열려 있지 않으면 0입니다.
+func (s *Searcher) DocCount() (uint64, error) {
+	if !s.indexManager.isOpen() {
+		return 0, nil
+	}
+	return s.indexManager.index.DocCount()
+}
+
+// LastRefresh는 원본 변경 여부를 마지막으로 확인한 시각입니다. 확인 기록이 없으면 원본을 내려받은 시각이며,
+// 둘 다 없으면 zero value입니다.
+func (s *Searcher) LastRefresh() time.Time {
+	metadata, err := s.cacheManager.LoadMetadata()
+	if err != nil {
+		return time.Time{}
+	}
+	if t, ok := parseMetadataTime(metadata.LastValidated); ok {
+		return t
+	}
+	t, _ := parseMetadataTime(metadata.LastFetched)
+	return t
+}
+
 // NewTestSearcher는 테스트용 Searcher를 생성합니다.
-// 실제 HTTP 호출 없이 인덱스 파일을 미리 생성하여
+// 실제 HTTP 호출 없이 인덱스 파일과 메타데이터를 미리 생성하여
 // EnsureIndex에서 OpenIndex가 성공하도록 합니다.
 func NewTestSearcher() (*Searcher, error) {
 	tempDir, err := os.MkdirTemp("", "test-searcher-*")
@@ -281,7 +595,7 @@ func NewTestSearcher() (*Searcher, error) {
 	indexPath := filepath.Join(tempDir, "test-index")
 
 	// 인덱스를 생성한 뒤 닫아서 파일만 남겨둔다.
-	// EnsureIndex → CheckETag 실패 → OpenIndex 경로로 진입하여 성공한다.
+	// 방금 확인한 것으로 기록해 두어 EnsureIndex → OpenIndex 성공 → 네트워크 확인 없이 기존 인덱스를 사용한다.
 	im := NewIndexManager(indexPath)
 	if err := im.CreateIndex(); err != nil {
 		return nil, err
@@ -292,6 +606,13 @@ func NewTestSearcher() (*Searcher, error) {
 		cacheDir:     tempDir,
 		metadataPath: filepath.Join(tempDir, "test-metadata.json"),
 		indexPath:    indexPath,
+		sourceDir:    filepath.Join(tempDir, "test-index"+sourceDirSuffix),
+	}
+	if err := cm.SaveETag("https://test.invalid/llms-full.txt", ""); err != nil {
+		return nil, err
+	}
+	if err := cm.MarkValidated(); err != nil {
+		return nil, err
 	}
 
 	return &Searcher{
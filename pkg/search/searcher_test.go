@@ -2,11 +2,17 @@ package search
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/toss/apps-in-toss-ax/pkg/llms"
 )
@@ -528,3 +534,409 @@ func TestTdsURLTransform(t *testing.T) {
 		}
 	}
 }
+
+// testCachedSearcher는 httptest 서버를 원본으로 사용하는 Searcher를 임시 캐시 디렉터리에 구성합니다
+func testCachedSearcher(t *testing.T, serverURL string) *Searcher {
+	t.Helper()
+
+	tempDir := t.TempDir()
+	indexPath := filepath.Join(tempDir, "search-index")
+
+	return &Searcher{
+		llmsFullUrl: serverURL + "/llms-full.txt",
+		llmsUrl:     serverURL + "/llms.txt",
+		cacheManager: &CacheManager{
+			cacheDir:     tempDir,
+			metadataPath: filepath.Join(tempDir, "cache-metadata.json"),
+			indexPath:    indexPath,
+			sourceDir:    indexPath + sourceDirSuffix,
+		},
+		indexManager: NewIndexManager(indexPath),
+		indexer:      appsInTossIndexer,
+	}
+}
+
+const testLlmsFullContent = `---
+url: >-
+  https://example.com/payment.md
+---
+# 결제 연동 가이드
+
+토스페이 결제를 연동하는 방법입니다.
+`
+
+// llmsServerCounts는 테스트 서버가 받은 llms-full.txt 요청 수입니다
+type llmsServerCounts struct {
+	fullFetches atomic.Int32 // 본문을 내려준 GET 요청
+	checks      atomic.Int32 // ETag 확인용 HEAD 요청
+}
+
+func newLlmsServer(t *testing.T, fullContent string) (*httptest.Server, *llmsServerCounts) {
+	t.Helper()
+
+	counts := &llmsServerCounts{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/llms-full.txt":
+			if r.Method == http.MethodHead {
+				counts.checks.Add(1)
+			}
+			w.Header().Set("ETag", `"v1"`)
+			if r.Header.Get("If-None-Match") == `"v1"` {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			if r.Method == http.MethodGet {
+				counts.fullFetches.Add(1)
+			}
+			fmt.Fprint(w, fullContent)
+		case "/llms.txt":
+			fmt.Fprint(w, "# Test\n\n## 결제\n\n- [결제 연동 가이드](https://example.com/payment.md)\n")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server, counts
+}
+
+func TestEnsureIndex_RebuildsOutdatedSchema(t *testing.T) {
+	server, counts := newLlmsServer(t, testLlmsFullContent)
+	s := testCachedSearcher(t, server.URL)
+	ctx := context.Background()
+
+	if err := s.EnsureIndex(ctx, nil); err != nil {
+		t.Fatalf("Initial EnsureIndex failed: %v", err)
+	}
+	s.Close()
+
+	metadata, err := s.cacheManager.LoadMetadata()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if metadata.SchemaVersion != IndexSchemaVersion {
+		t.Errorf("Expected metadata schema version %d, got %d", IndexSchemaVersion, metadata.SchemaVersion)
+	}
+
+	// 같은 ETag면 다시 받지 않아야 함
+	if err := s.EnsureIndex(ctx, nil); err != nil {
+		t.Fatalf("Second EnsureIndex failed: %v", err)
+	}
+	s.Close()
+	if got := counts.fullFetches.Load(); got != 1 {
+		t.Fatalf("Expected 1 fetch with unchanged schema, got %d", got)
+	}
+
+	// 이전 버전 매핑으로 만든 캐시를 흉내 낸다
+	metadata.SchemaVersion = IndexSchemaVersion - 1
+	data, err := json.Marshal(metadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(s.cacheManager.metadataPath, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.EnsureIndex(ctx, nil); err != nil {
+		t.Fatalf("EnsureIndex with outdated schema failed: %v", err)
+	}
+	defer s.Close()
+
+	// 재색인은 로컬에 저장된 원본으로 이루어져야 함
+	if got := counts.fullFetches.Load(); got != 1 {
+		t.Errorf("Expected outdated schema to rebuild without fetching, got %d fetches", got)
+	}
+
+	version, err := s.indexManager.SchemaVersion()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version != IndexSchemaVersion {
+		t.Errorf("Expected rebuilt index schema version %d, got %d", IndexSchemaVersion, version)
+	}
+
+	results, err := s.Search(ctx, "결제", nil)
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(results) == 0 {
+		t.Error("Expected results from rebuilt index")
+	}
+}
+
+func TestIndexManager_SchemaVersion(t *testing.T) {
+	indexPath := filepath.Join(t.TempDir(), "search-index")
+
+	im := NewIndexManager(indexPath)
+	if err := im.BuildIndex(nil); err != nil {
+		t.Fatalf("BuildIndex failed: %v", err)
+	}
+	defer im.Close()
+
+	version, err := im.SchemaVersion()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version != IndexSchemaVersion {
+		t.Errorf("Expected schema version %d, got %d", IndexSchemaVersion, version)
+	}
+}
+
+func TestEnsureIndex_RebuildsFromCachedSourceOffline(t *testing.T) {
+	server, _ := newLlmsServer(t, testLlmsFullContent)
+	s := testCachedSearcher(t, server.URL)
+	ctx := context.Background()
+
+	if err := s.EnsureIndex(ctx, nil); err != nil {
+		t.Fatalf("Initial EnsureIndex failed: %v", err)
+	}
+	s.Close()
+
+	source, err := s.cacheManager.LoadSource(llmsFullSourceName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if source == nil || source.ETag != `"v1"` || source.Content != testLlmsFullContent {
+		t.Fatalf("Expected cached llms-full source with ETag, got %+v", source)
+	}
+
+	// 네트워크가 끊기고 인덱스도 사라진 상황
+	server.Close()
+	if err := s.cacheManager.DeleteIndex(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.EnsureIndex(ctx, nil); err != nil {
+		t.Fatalf("EnsureIndex offline failed: %v", err)
+	}
+	defer s.Close()
+
+	results, err := s.Search(ctx, "결제", nil)
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(results) == 0 {
+		t.Fatal("Expected results from index rebuilt offline")
+	}
+	if results[0].Category != "결제" {
+		t.Errorf("Expected category from cached llms.txt, got %q", results[0].Category)
+	}
+}
+
+func TestCacheManager_SourceRoundTrip(t *testing.T) {
+	tempDir := t.TempDir()
+	cm := &CacheManager{sourceDir: filepath.Join(tempDir, "search-index"+sourceDirSuffix)}
+
+	source, err := cm.LoadSource(llmsFullSourceName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if source != nil {
+		t.Fatalf("Expected no cached source, got %+v", source)
+	}
+
+	want := RawSource{
+		URL:          "https://example.com/llms-full.txt",
+		ETag:         `"abc"`,
+		LastModified: "Wed, 21 Oct 2015 07:28:00 GMT",
+		Content:      testLlmsFullContent,
+	}
+	if err := cm.SaveSource(llmsFullSourceName, want); err != nil {
+		t.Fatalf("SaveSource failed: %v", err)
+	}
+
+	got, err := cm.LoadSource(llmsFullSourceName)
+	if err != nil {
+		t.Fatalf("LoadSource failed: %v", err)
+	}
+	if got.URL != want.URL || got.ETag != want.ETag || got.LastModified != want.LastModified || got.Content != want.Content {
+		t.Errorf("Round trip mismatch: got %+v", got)
+	}
+	if got.FetchedAt == "" {
+		t.Error("Expected FetchedAt to be set")
+	}
+}
+
+func TestEnsureIndex_FreshnessTTL(t *testing.T) {
+	server, counts := newLlmsServer(t, testLlmsFullContent)
+	s := testCachedSearcher(t, server.URL)
+	ctx := context.Background()
+
+	if err := s.EnsureIndex(ctx, nil); err != nil {
+		t.Fatalf("Initial EnsureIndex failed: %v", err)
+	}
+	s.Close()
+
+	// 방금 내려받았으므로 TTL 안에서는 확인 요청을 보내지 않아야 함
+	if err := s.EnsureIndex(ctx, nil); err != nil {
+		t.Fatalf("EnsureIndex failed: %v", err)
+	}
+	s.Close()
+	if got := counts.checks.Load(); got != 0 {
+		t.Errorf("Expected no freshness check within TTL, got %d", got)
+	}
+
+	// --refresh는 TTL과 관계없이 확인해야 함
+	s.SetRefreshPolicy(RefreshPolicy{Force: true})
+	if err := s.EnsureIndex(ctx, nil); err != nil {
+		t.Fatalf("EnsureIndex with Force failed: %v", err)
+	}
+	s.Close()
+	if got := counts.checks.Load(); got != 1 {
+		t.Errorf("Expected 1 freshness check with Force, got %d", got)
+	}
+
+	// TTL이 지나면 다시 확인해야 함
+	s.SetRefreshPolicy(RefreshPolicy{FreshnessTTL: time.Second})
+	if err := s.cacheManager.updateMetadata(func(m *CacheMetadata) {
+		m.LastValidated = time.Now().Add(-time.Minute).UTC().Format(time.RFC3339)
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.EnsureIndex(ctx, nil); err != nil {
+		t.Fatalf("EnsureIndex after TTL failed: %v", err)
+	}
+	s.Close()
+	if got := counts.checks.Load(); got != 2 {
+		t.Errorf("Expected freshness check after TTL, got %d", got)
+	}
+
+	metadata, err := s.cacheManager.LoadMetadata()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if metadata.FreshnessTTLSeconds != 1 {
+		t.Errorf("Expected TTL to be stored in metadata, got %d", metadata.FreshnessTTLSeconds)
+	}
+}
+
+func TestEnsureIndex_CancelDoesNotMarkCheckFailed(t *testing.T) {
+	server, _ := newLlmsServer(t, testLlmsFullContent)
+	s := testCachedSearcher(t, server.URL)
+	if err := s.EnsureIndex(context.Background(), nil); err != nil {
+		t.Fatalf("Initial EnsureIndex failed: %v", err)
+	}
+	s.Close()
+	s.SetRefreshPolicy(RefreshPolicy{Force: true})
+
+	lastCheckFailed := func() string {
+		t.Helper()
+		metadata, err := s.cacheManager.LoadMetadata()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return metadata.LastCheckFailed
+	}
+
+	// 취소된 호출(Ctrl-C)은 네트워크 실패로 기록하지 않아야 함
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := s.EnsureIndex(canceled, nil); err != nil {
+		t.Fatalf("EnsureIndex with a canceled context failed: %v", err)
+	}
+	s.Close()
+	if got := lastCheckFailed(); got != "" {
+		t.Errorf("Expected no recorded failure after cancellation, got %q", got)
+	}
+}
+
+func TestCacheManager_NeedsValidation(t *testing.T) {
+	tempDir := t.TempDir()
+	cm := &CacheManager{metadataPath: filepath.Join(tempDir, "cache-metadata.json")}
+	now := time.Now()
+
+	if !cm.NeedsValidation(now) {
+		t.Error("Expected validation without metadata")
+	}
+
+	if err := cm.MarkValidated(); err != nil {
+		t.Fatal(err)
+	}
+	if cm.NeedsValidation(now) {
+		t.Error("Expected no validation right after MarkValidated")
+	}
+	if !cm.NeedsValidation(now.Add(DefaultFreshnessTTL + time.Minute)) {
+		t.Error("Expected validation after DefaultFreshnessTTL")
+	}
+
+	// 네트워크 실패는 짧게 기억해 매 호출마다 재시도하지 않아야 함
+	later := now.Add(DefaultFreshnessTTL + time.Minute)
+	if err := cm.updateMetadata(func(m *CacheMetadata) {
+		m.LastCheckFailed = later.UTC().Format(time.RFC3339)
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if cm.NeedsValidation(later.Add(time.Minute)) {
+		t.Error("Expected recent network failure to skip validation")
+	}
+	if !cm.NeedsValidation(later.Add(offlineRetryInterval + time.Minute)) {
+		t.Error("Expected validation once the failure is older than offlineRetryInterval")
+	}
+}
+
+func TestSearcher_OpenCachedIndex(t *testing.T) {
+	s, err := NewTestSearcher()
+	if err != nil {
+		t.Fatalf("NewTestSearcher failed: %v", err)
+	}
+	defer os.RemoveAll(s.cacheManager.cacheDir)
+
+	if err := s.OpenCachedIndex(); err != nil {
+		t.Fatalf("OpenCachedIndex failed: %v", err)
+	}
+	defer s.Close()
+
+	if s.NeedsRefresh() {
+		t.Error("Expected no refresh right after validation")
+	}
+	count, err := s.DocCount()
+	if err != nil || count != 0 {
+		t.Errorf("DocCount = %d, %v; want 0, nil", count, err)
+	}
+	if since := time.Since(s.LastRefresh()); since < 0 || since > time.Minute {
+		t.Errorf("Unexpected LastRefresh: %v", s.LastRefresh())
+	}
+
+	// 디스크에 인덱스가 없으면 네트워크로 만들지 않고 실패해야 함
+	empty, err := NewTestSearcher()
+	if err != nil {
+		t.Fatalf("NewTestSearcher failed: %v", err)
+	}
+	defer os.RemoveAll(empty.cacheManager.cacheDir)
+	if err := empty.cacheManager.DeleteIndex(); err != nil {
+		t.Fatalf("DeleteIndex failed: %v", err)
+	}
+	if err := empty.OpenCachedIndex(); !errors.Is(err, errNoCachedIndex) {
+		t.Errorf("Expected errNoCachedIndex, got %v", err)
+	}
+	if count, err := empty.DocCount(); err != nil || count != 0 {
+		t.Errorf("DocCount on closed index = %d, %v; want 0, nil", count, err)
+	}
+}
+
+func TestEnsureIndex_ReportsProgress(t *testing.T) {
+	server, _ := newLlmsServer(t, testLlmsFullContent)
+	s := testCachedSearcher(t, server.URL)
+
+	var stages []ProgressStage
+	var last Progress
+	err := s.EnsureIndex(context.Background(), func(p Progress) {
+		if len(stages) == 0 || stages[len(stages)-1] != p.Stage {
+			stages = append(stages, p.Stage)
+		}
+		last = p
+	})
+	if err != nil {
+		t.Fatalf("EnsureIndex failed: %v", err)
+	}
+	defer s.Close()
+
+	want := []ProgressStage{StageFetching, StageParsing, StageIndexing, StageDone}
+	if fmt.Sprint(stages) != fmt.Sprint(want) {
+		t.Errorf("Expected stages %v, got %v", want, stages)
+	}
+	if last.Current != 1 || last.Total != 1 || last.Message != "index ready: 1 documents" {
+		t.Errorf("Unexpected final progress %+v", last)
+	}
+}
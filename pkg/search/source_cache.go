@@ -0,0 +1,88 @@
+package search
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// 원본 캐시는 인덱스 디렉터리 옆의 "<IndexSubDir>-source" 디렉터리에 저장됩니다
+	sourceDirSuffix = "-source"
+	sourceFileExt   = ".json.gz"
+
+	llmsFullSourceName = "llms-full"
+	llmsSourceName     = "llms"
+)
+
+// RawSource는 인덱스를 만들 때 사용한 원본 문서(llms.txt, llms-full.txt)와 그 검증 값입니다.
+// 인덱스를 다시 만들어야 할 때 네트워크 없이 이 원본으로 재색인합니다.
+type RawSource struct {
+	URL          string `json:"url"`
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"last_modified,omitempty"`
+	FetchedAt    string `json:"fetched_at"`
+	Content      string `json:"content"`
+}
+
+// SaveSource는 원본 문서를 gzip으로 압축해 저장합니다
+func (cm *CacheManager) SaveSource(name string, source RawSource) error {
+	if source.FetchedAt == "" {
+		source.FetchedAt = time.Now().UTC().Format(time.RFC3339)
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if err := json.NewEncoder(zw).Encode(source); err != nil {
+		zw.Close()
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(cm.sourceDir, 0755); err != nil {
+		return err
+	}
+
+	return writeFileAtomic(cm.sourcePath(name), buf.Bytes())
+}
+
+// LoadSource는 저장된 원본 문서를 읽습니다.
+// 저장된 원본이 없으면 nil을 반환합니다.
+func (cm *CacheManager) LoadSource(name string) (*RawSource, error) {
+	f, err := os.Open(cm.sourcePath(name))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+
+	data, err := io.ReadAll(zr)
+	if err != nil {
+		return nil, err
+	}
+
+	var source RawSource
+	if err := json.Unmarshal(data, &source); err != nil {
+		return nil, err
+	}
+
+	return &source, nil
+}
+
+func (cm *CacheManager) sourcePath(name string) string {
+	return filepath.Join(cm.sourceDir, name+sourceFileExt)
+}
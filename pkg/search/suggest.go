@@ -0,0 +1,446 @@
+package search
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/blevesearch/bleve/v2"
+	index "github.com/blevesearch/bleve_index_api"
+)
+
+const (
+	// DefaultSuggestionLimit는 Suggest와 Complete가 기본으로 반환하는 개수입니다
+	DefaultSuggestionLimit = 5
+
+	// 단어마다 남기는 교정 후보 수
+	maxWordCandidates = 3
+
+	// 교정 대상이 되는 최소 길이 (룬 단위). 이보다 짧은 영어 단어는 후보가 너무 많아 교정하지 않습니다.
+	minCorrectableLength = 3
+
+	// 한글 음절 하나를 바꿀 때 허용하는 자모 편집 거리
+	maxSyllableJamoDistance = 1
+)
+
+// suggestFields는 교정 사전을 만드는 필드입니다
+var suggestFields = []string{"title", "content"}
+
+// suggester는 인덱스의 단어 사전으로 만든 맞춤법 교정/자동 완성 사전입니다.
+// cjk_analyzer는 한글을 두 음절(bigram)로, 그 밖의 단어는 edge n-gram 접두어로 색인하므로
+// 한글은 음절 bigram과 인접 음절 목록으로, 그 밖의 단어는 접두어 조각을 걸러낸 단어 목록으로 보관합니다.
+type suggester struct {
+	words    map[string]uint64 // 한글이 아닌 단어와 문서 빈도
+	wordList []string          // words를 정렬한 목록 (접두어 완성용)
+	bigrams  map[string]uint64 // 한글 두 음절 조각과 문서 빈도
+	next     map[rune][]rune   // 음절 뒤에 올 수 있는 음절 (빈도순)
+	prev     map[rune][]rune   // 음절 앞에 올 수 있는 음절 (빈도순)
+}
+
+// suggesterCache는 같은 인덱스(문서 수 포함)에 대해 사전을 다시 만들지 않도록 보관합니다
+type suggesterCache struct {
+	index     bleve.Index
+	docCount  uint64
+	suggester *suggester
+}
+
+// suggester는 현재 인덱스의 교정 사전을 반환합니다. 인덱스가 바뀌면 다시 만듭니다.
+func (im *IndexManager) suggester() (*suggester, error) {
+	docCount, err := im.index.DocCount()
+	if err != nil {
+		return nil, err
+	}
+
+	im.suggestMu.Lock()
+	defer im.suggestMu.Unlock()
+
+	if c := im.suggestCache; c != nil && c.index == im.index && c.docCount == docCount {
+		return c.suggester, nil
+	}
+
+	sg, err := im.buildSuggester()
+	if err != nil {
+		return nil, err
+	}
+	im.suggestCache = &suggesterCache{index: im.index, docCount: docCount, suggester: sg}
+	return sg, nil
+}
+
+// buildSuggester는 title과 content 필드의 단어 사전을 읽어 교정 사전을 만듭니다
+func (im *IndexManager) buildSuggester() (*suggester, error) {
+	advanced, err := im.index.Advanced()
+	if err != nil {
+		return nil, err
+	}
+	reader, err := advanced.Reader()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	terms := map[string]uint64{}
+	for _, field := range suggestFields {
+		if err := collectTerms(reader, field, terms); err != nil {
+			return nil, err
+		}
+	}
+	return newSuggester(terms), nil
+}
+
+// collectTerms는 field의 단어 사전을 terms에 더합니다. 필드별 문서 빈도는 합산합니다.
+func collectTerms(reader index.IndexReader, field string, terms map[string]uint64) error {
+	dict, err := reader.FieldDict(field)
+	if err != nil {
+		return err
+	}
+	defer dict.Close()
+
+	for {
+		entry, err := dict.Next()
+		if err != nil {
+			return err
+		}
+		if entry == nil {
+			return nil
+		}
+		terms[entry.Term] += entry.Count
+	}
+}
+
+// newSuggester는 인덱스 단어와 문서 빈도로 교정 사전을 만듭니다.
+// edge n-gram이 만든 접두어 조각("butto", "nav")은 그 문서가 모두 더 긴 단어("button", "navigation", "navbar")를
+// 포함하므로, 한 글자 더 긴 단어들의 문서 빈도 합이 자기 빈도 이상이면 단어가 아닌 것으로 보고 제외합니다.
+func newSuggester(terms map[string]uint64) *suggester {
+	sg := &suggester{
+		words:   map[string]uint64{},
+		bigrams: map[string]uint64{},
+		next:    map[rune][]rune{},
+		prev:    map[rune][]rune{},
+	}
+
+	// 한 글자 더 긴 단어들의 문서 빈도 합
+	extended := map[string]uint64{}
+	for term, count := range terms {
+		if utf8.RuneCountInString(term) < 2 || isHangulWord(term) {
+			continue
+		}
+		_, size := utf8.DecodeLastRuneInString(term)
+		extended[term[:len(term)-size]] += count
+	}
+
+	for term, count := range terms {
+		runes := []rune(term)
+		switch {
+		case len(runes) < 2:
+		case isHangulWord(term):
+			if len(runes) == 2 {
+				sg.bigrams[term] = count
+				sg.next[runes[0]] = append(sg.next[runes[0]], runes[1])
+				sg.prev[runes[1]] = append(sg.prev[runes[1]], runes[0])
+			}
+		case extended[term] < count && isPlainWord(term):
+			sg.words[term] = count
+			sg.wordList = append(sg.wordList, term)
+		}
+	}
+
+	sort.Strings(sg.wordList)
+	for r, following := range sg.next {
+		sort.Slice(following, func(i, j int) bool {
+			a, b := sg.bigramCount(r, following[i]), sg.bigramCount(r, following[j])
+			return a > b || a == b && following[i] < following[j]
+		})
+	}
+	for r, preceding := range sg.prev {
+		sort.Slice(preceding, func(i, j int) bool {
+			a, b := sg.bigramCount(preceding[i], r), sg.bigramCount(preceding[j], r)
+			return a > b || a == b && preceding[i] < preceding[j]
+		})
+	}
+	return sg
+}
+
+func (sg *suggester) bigramCount(a, b rune) uint64 {
+	return sg.bigrams[string([]rune{a, b})]
+}
+
+// wordCandidate는 단어 하나의 교정 후보입니다
+type wordCandidate struct {
+	text     string
+	distance int
+	count    uint64
+}
+
+// correct는 사전에 없는 단어의 교정 후보를 가까운 순서로 반환합니다.
+// 사전에 있거나 교정할 수 없는 단어이면 nil입니다.
+func (sg *suggester) correct(word string) []wordCandidate {
+	switch {
+	case isHangulWord(word):
+		return sg.correctHangul(word)
+	case isPlainWord(word):
+		return sg.correctWord(strings.ToLower(word))
+	default:
+		return nil
+	}
+}
+
+// correctWord는 영어 단어를 편집 거리(4글자 이하 1, 그 이상 2)로 교정합니다.
+// 인덱스는 앞 10글자만 보관하므로 긴 단어는 앞부분을 교정하고 나머지를 그대로 붙입니다.
+func (sg *suggester) correctWord(word string) []wordCandidate {
+	head := indexedTerm(word)
+	rest := word[len(head):]
+	if sg.words[head] > 0 || utf8.RuneCountInString(head) < minCorrectableLength {
+		return nil
+	}
+
+	target := []rune(head)
+	maxDistance := 2
+	if len(target) <= 4 {
+		maxDistance = 1
+	}
+
+	var candidates []wordCandidate
+	for _, w := range sg.wordList {
+		runes := []rune(w)
+		if abs(len(runes)-len(target)) > maxDistance {
+			continue
+		}
+		if d := editDistance(target, runes); d <= maxDistance {
+			candidates = append(candidates, wordCandidate{text: w + rest, distance: d, count: sg.words[w]})
+		}
+	}
+	return rankCandidates(candidates)
+}
+
+// correctHangul은 한글 단어의 음절 하나를 자모가 비슷한 음절로 바꿔, 모든 인접 음절 쌍이
+// 인덱스에 있는 단어를 찾습니다. 예를 들어 "겔제"는 "결제"로 교정됩니다.
+func (sg *suggester) correctHangul(word string) []wordCandidate {
+	runes := []rune(word)
+	if len(runes) < 2 || sg.knownHangul(runes) {
+		return nil
+	}
+	// "결제를"처럼 조사가 붙은 단어는 조사 앞까지 알려진 단어이면 그대로 둡니다
+	if len(runes) >= 3 && sg.knownHangul(runes[:len(runes)-1]) {
+		return nil
+	}
+
+	var candidates []wordCandidate
+	for i, original := range runes {
+		var options []rune
+		if i > 0 {
+			options = sg.next[runes[i-1]]
+		} else {
+			options = sg.prev[runes[i+1]]
+		}
+
+		for _, option := range options {
+			if option == original || jamoDistance(string(original), string(option)) > maxSyllableJamoDistance {
+				continue
+			}
+			replaced := append([]rune(nil), runes...)
+			replaced[i] = option
+			if !sg.knownHangul(replaced) {
+				continue
+			}
+			candidates = append(candidates, wordCandidate{
+				text:     string(replaced),
+				distance: jamoDistance(word, string(replaced)),
+				count:    sg.minBigramCount(replaced),
+			})
+		}
+	}
+	return rankCandidates(candidates)
+}
+
+// knownHangul은 인접한 모든 음절 쌍이 인덱스에 있는지 확인합니다
+func (sg *suggester) knownHangul(runes []rune) bool {
+	for i := 0; i+1 < len(runes); i++ {
+		if sg.bigramCount(runes[i], runes[i+1]) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (sg *suggester) minBigramCount(runes []rune) uint64 {
+	var lowest uint64
+	for i := 0; i+1 < len(runes); i++ {
+		if count := sg.bigramCount(runes[i], runes[i+1]); i == 0 || count < lowest {
+			lowest = count
+		}
+	}
+	return lowest
+}
+
+// rankCandidates는 편집 거리가 가깝고 문서 빈도가 높은 순서로 정렬해 상위 후보만 남깁니다
+func rankCandidates(candidates []wordCandidate) []wordCandidate {
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].distance != candidates[j].distance {
+			return candidates[i].distance < candidates[j].distance
+		}
+		if candidates[i].count != candidates[j].count {
+			return candidates[i].count > candidates[j].count
+		}
+		return candidates[i].text < candidates[j].text
+	})
+	if len(candidates) > maxWordCandidates {
+		candidates = candidates[:maxWordCandidates]
+	}
+	return candidates
+}
+
+// suggest는 query의 각 단어를 교정한 쿼리를 반환합니다.
+// 가장 가까운 후보로 모두 바꾼 쿼리가 먼저 오고, 그다음 단어 하나만 차순위 후보로 바꾼 쿼리가 옵니다.
+func (sg *suggester) suggest(query string, limit int) []string {
+	words := strings.Fields(query)
+	candidates := make([][]wordCandidate, len(words))
+	corrected := false
+	for i, word := range words {
+		lead, core, trail := splitWord(word)
+		for _, c := range sg.correct(core) {
+			c.text = lead + matchCase(core, c.text) + trail
+			candidates[i] = append(candidates[i], c)
+		}
+		corrected = corrected || len(candidates[i]) > 0
+	}
+	if !corrected {
+		return nil
+	}
+
+	best := make([]string, len(words))
+	for i, word := range words {
+		best[i] = word
+		if len(candidates[i]) > 0 {
+			best[i] = candidates[i][0].text
+		}
+	}
+
+	suggestions := []string{strings.Join(best, " ")}
+	for i := range words {
+		for _, alternative := range candidates[i][min(1, len(candidates[i])):] {
+			variant := append([]string(nil), best...)
+			variant[i] = alternative.text
+			suggestions = append(suggestions, strings.Join(variant, " "))
+		}
+	}
+	if len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+	return suggestions
+}
+
+// complete는 query의 마지막 단어를 인덱스에 있는 단어로 완성한 쿼리를 빈도순으로 반환합니다.
+// 한글은 마지막 음절 뒤에 자주 오는 음절을 하나 붙이고, 그 밖의 단어는 같은 접두어로 시작하는 단어를 찾습니다.
+func (sg *suggester) complete(query string, limit int) []string {
+	trimmed := strings.TrimRightFunc(query, unicode.IsSpace)
+	if trimmed == "" || len(trimmed) < len(query) {
+		return nil
+	}
+	start := strings.LastIndexFunc(trimmed, unicode.IsSpace) + 1
+	head, last := trimmed[:start], trimmed[start:]
+
+	var completions []wordCandidate
+	switch {
+	case isHangulWord(last):
+		runes := []rune(last)
+		tail := runes[len(runes)-1]
+		if len(runes) > 1 && !sg.knownHangul(runes) {
+			return nil
+		}
+		for _, r := range sg.next[tail] {
+			completions = append(completions, wordCandidate{text: last + string(r), count: sg.bigramCount(tail, r)})
+		}
+	case isPlainWord(last):
+		prefix := strings.ToLower(last)
+		from := sort.SearchStrings(sg.wordList, prefix)
+		for _, w := range sg.wordList[from:] {
+			if !strings.HasPrefix(w, prefix) {
+				break
+			}
+			completions = append(completions, wordCandidate{text: matchCase(last, w), count: sg.words[w]})
+		}
+	}
+
+	sort.SliceStable(completions, func(i, j int) bool {
+		return completions[i].count > completions[j].count
+	})
+	if len(completions) > limit {
+		completions = completions[:limit]
+	}
+
+	results := make([]string, len(completions))
+	for i, c := range completions {
+		results[i] = head + c.text
+	}
+	return results
+}
+
+// splitWord는 단어 앞뒤의 따옴표, +, - 같은 기호를 떼어 냅니다
+func splitWord(word string) (lead, core, trail string) {
+	isSymbol := func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }
+	core = strings.TrimLeftFunc(word, isSymbol)
+	lead = word[:len(word)-len(core)]
+	core = strings.TrimRightFunc(core, isSymbol)
+	trail = word[len(lead)+len(core):]
+	return lead, core, trail
+}
+
+// isPlainWord는 s가 한글이 아닌 글자와 숫자로만 이루어졌는지 확인합니다
+func isPlainWord(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if isHangulSyllable(r) || !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchCase는 원래 단어가 대문자로 시작하면 교정한 단어도 대문자로 시작하게 합니다 ("Buton" → "Button")
+func matchCase(original, corrected string) string {
+	first, _ := utf8.DecodeRuneInString(original)
+	if !unicode.IsUpper(first) {
+		return corrected
+	}
+	r, size := utf8.DecodeRuneInString(corrected)
+	return string(unicode.ToUpper(r)) + corrected[size:]
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// Suggest는 맞춤법이 틀린 단어를 인덱스의 단어로 교정한 쿼리를 limit개까지 반환합니다.
+// 모든 단어가 인덱스에 있거나 고칠 후보가 없으면 nil을 반환합니다.
+func (s *Searcher) Suggest(ctx context.Context, query string, limit int) ([]string, error) {
+	if limit <= 0 {
+		limit = DefaultSuggestionLimit
+	}
+
+	sg, err := s.indexManager.suggester()
+	if err != nil {
+		return nil, err
+	}
+	return sg.suggest(query, limit), nil
+}
+
+// Complete는 입력 중인 query의 마지막 단어를 인덱스에 있는 단어로 완성한 쿼리를 limit개까지 반환합니다.
+// 마지막 글자가 공백이면 완성할 단어가 없으므로 nil을 반환합니다.
+func (s *Searcher) Complete(ctx context.Context, query string, limit int) ([]string, error) {
+	if limit <= 0 {
+		limit = DefaultSuggestionLimit
+	}
+
+	sg, err := s.indexManager.suggester()
+	if err != nil {
+		return nil, err
+	}
+	return sg.complete(query, limit), nil
+}
@@ -0,0 +1,134 @@
+package search
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestJamoDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"결제", "결제", 0},
+		{"겔제", "결제", 1}, // 모음 하나
+		{"겨제", "결제", 1}, // 받침 누락
+		{"결재", "결제", 1},
+		{"환불", "결제", 6},
+		{"button", "buton", 1},
+	}
+
+	for _, tt := range tests {
+		if got := jamoDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("jamoDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestEditDistance_Transposition(t *testing.T) {
+	if got := editDistance([]rune("textfeild"), []rune("textfield")); got != 1 {
+		t.Errorf("Expected transposition to cost 1, got %d", got)
+	}
+}
+
+func TestSplitWord(t *testing.T) {
+	lead, core, trail := splitWord(`+"Buton",`)
+	if lead != `+"` || core != "Buton" || trail != `",` {
+		t.Errorf("splitWord = (%q, %q, %q)", lead, core, trail)
+	}
+}
+
+func TestSearcher_Suggest(t *testing.T) {
+	s, tempDir := testSearcher(t, appsInTossIndexer, nil)
+	defer os.RemoveAll(tempDir)
+
+	if err := s.indexManager.CreateIndex(); err != nil {
+		t.Fatalf("Failed to create index: %v", err)
+	}
+	defer s.Close()
+
+	docs := []IndexDocument{
+		{ID: "payment", Title: "결제 연동", Content: "토스페이 결제를 연동합니다. 결제 결과를 확인합니다."},
+		{ID: "refund", Title: "환불", Content: "결제를 취소하고 환불합니다."},
+		{ID: "button", Title: "Button", Content: "Button 컴포넌트로 버튼을 만듭니다."},
+		{ID: "textfield", Title: "TextField", Content: "TextField로 입력을 받습니다."},
+		{ID: "navigation", Title: "Navigation", Content: "useNavigation으로 화면을 이동합니다."},
+	}
+	if err := s.indexManager.IndexDocuments(docs); err != nil {
+		t.Fatalf("Failed to index: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{"english typo keeps capitalization", "Buton", "Button"},
+		{"transposed letters", "textfeild", "textfield"},
+		{"korean vowel typo", "겔제 연동", "결제 연동"},
+		{"syntax symbols are kept", `+"Navigaton"`, `+"Navigation"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suggestions, err := s.Suggest(context.Background(), tt.query, 0)
+			if err != nil {
+				t.Fatalf("Suggest failed: %v", err)
+			}
+			if len(suggestions) == 0 || suggestions[0] != tt.want {
+				t.Errorf("Suggest(%q) = %v, want %q first", tt.query, suggestions, tt.want)
+			}
+		})
+	}
+
+	for _, query := range []string{"결제 연동", "Button", "결제를", "ui"} {
+		suggestions, err := s.Suggest(context.Background(), query, 0)
+		if err != nil {
+			t.Fatalf("Suggest failed: %v", err)
+		}
+		if suggestions != nil {
+			t.Errorf("Expected no suggestions for %q, got %v", query, suggestions)
+		}
+	}
+}
+
+func TestSearcher_Complete(t *testing.T) {
+	s, tempDir := testSearcher(t, appsInTossIndexer, nil)
+	defer os.RemoveAll(tempDir)
+
+	if err := s.indexManager.CreateIndex(); err != nil {
+		t.Fatalf("Failed to create index: %v", err)
+	}
+	defer s.Close()
+
+	docs := []IndexDocument{
+		{ID: "payment", Title: "결제 연동", Content: "결제 결과를 확인합니다. 결제 수단을 고릅니다."},
+		{ID: "refund", Title: "환불", Content: "결제를 취소합니다."},
+		{ID: "navigation", Title: "Navigation", Content: "Navigation과 NavBar를 설명합니다."},
+		{ID: "navbar", Title: "NavBar", Content: "Navigation 상단 바입니다."},
+	}
+	if err := s.indexManager.IndexDocuments(docs); err != nil {
+		t.Fatalf("Failed to index: %v", err)
+	}
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"결", []string{"결제", "결과"}},
+		{"토스 Nav", []string{"토스 Navigation", "토스 Navbar"}},
+		{"결제 ", nil},
+	}
+
+	for _, tt := range tests {
+		got, err := s.Complete(context.Background(), tt.query, 2)
+		if err != nil {
+			t.Fatalf("Complete failed: %v", err)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Complete(%q) = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}